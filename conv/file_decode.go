@@ -20,6 +20,7 @@ package conv
 import (
 	"fmt"
 	"io"
+	"sync"
 
 	"golang.org/x/net/html/charset"
 	"golang.org/x/text/encoding"
@@ -27,7 +28,8 @@ import (
 )
 
 type Decoder struct {
-	e encoding.Encoding
+	e    encoding.Encoding
+	pool sync.Pool // reusable transformers of e, they are reset by transform.Bytes and transform.String
 }
 
 // NewDecoder new encoder will use HTML escape sequences for runes that are not supported by the character set.
@@ -36,9 +38,18 @@ func NewDecoder(charSet string) (*Decoder, error) {
 	if e == nil {
 		return nil, fmt.Errorf("invalid charset [%s]", charSet)
 	}
-	return &Decoder{e: e}, nil
+	d := &Decoder{e: e}
+	d.pool.New = func() any { return e.NewDecoder() }
+	return d, nil
 }
 
+// transformer gets a transformer from the pool, it must be put back by putTransformer.
+func (d *Decoder) transformer() transform.Transformer {
+	return d.pool.Get().(transform.Transformer)
+}
+
+func (d *Decoder) putTransformer(t transform.Transformer) { d.pool.Put(t) }
+
 // GetEncoding get HTML character set encoder
 func (d *Decoder) GetEncoding() encoding.Encoding {
 	return d.e
@@ -52,7 +63,9 @@ func (d *Decoder) GetReader(r io.Reader) *transform.Reader {
 // ByteToString returns a new string with the result of converting b[:n] using t,
 // where n <= len(b). If err == nil, n will be len(b). It calls Reset on t.
 func (d *Decoder) ByteToString(src []byte) (string, error) {
-	dst, _, err := transform.Bytes(d.e.NewDecoder(), src)
+	t := d.transformer()
+	dst, _, err := transform.Bytes(t, src)
+	d.putTransformer(t)
 	if err != nil {
 		return "", err
 	}
@@ -62,7 +75,9 @@ func (d *Decoder) ByteToString(src []byte) (string, error) {
 // ByteToByte returns a new byte slice with the result of converting b[:n] using t,
 // where n <= len(b). If err == nil, n will be len(b). It calls Reset on t.
 func (d *Decoder) ByteToByte(src []byte) ([]byte, error) {
-	dst, _, err := transform.Bytes(d.e.NewDecoder(), src)
+	t := d.transformer()
+	dst, _, err := transform.Bytes(t, src)
+	d.putTransformer(t)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +87,9 @@ func (d *Decoder) ByteToByte(src []byte) ([]byte, error) {
 // StringToByte returns a byte slice with the result of converting s[:n] using t, where
 // n <= len(s). If err == nil, n will be len(s). It calls Reset on t.
 func (d *Decoder) StringToByte(src string) ([]byte, error) {
-	dst, _, err := transform.String(d.e.NewDecoder(), src)
+	t := d.transformer()
+	dst, _, err := transform.String(t, src)
+	d.putTransformer(t)
 	if err != nil {
 		return nil, err
 	}
@@ -82,9 +99,20 @@ func (d *Decoder) StringToByte(src string) ([]byte, error) {
 // StringToString returns a string with the result of converting s[:n] using t, where
 // n <= len(s). If err == nil, n will be len(s). It calls Reset on t.
 func (d *Decoder) StringToString(src string) (string, error) {
-	dst, _, err := transform.String(d.e.NewDecoder(), src)
+	t := d.transformer()
+	dst, _, err := transform.String(t, src)
+	d.putTransformer(t)
 	if err != nil {
 		return "", err
 	}
 	return dst, nil
 }
+
+// ReaderToString reads r until EOF, and returns the result of converting the read bytes.
+func (d *Decoder) ReaderToString(r io.Reader) (string, error) {
+	dst, err := io.ReadAll(d.GetReader(r))
+	if err != nil {
+		return "", err
+	}
+	return BytesToString(dst), nil
+}
@@ -0,0 +1,87 @@
+// Package conv
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package conv
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/text/transform"
+)
+
+// "黄昏よりも昏きもの" in Shift_JIS
+var sjisBytes = []byte("\x89\xa9\x8d\xa8\x82\xe6\x82\xe8\x82\xe0\x8d\xa8\x82\xab\x82\xe0\x82\xcc")
+
+func TestDecoder_ByteToString(t *testing.T) {
+	d, err := NewDecoder("shift_jis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				got, err := d.ByteToString(sjisBytes)
+				if err != nil {
+					t.Errorf("ByteToString() error = %v", err)
+					return
+				}
+				if got != "黄昏よりも昏きもの" {
+					t.Errorf("ByteToString() = %v, want %v", got, "黄昏よりも昏きもの")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDecoder_ReaderToString(t *testing.T) {
+	d, err := NewDecoder("shift_jis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := bytes.Repeat(append(sjisBytes, '\n'), 1000)
+	got, err := d.ReaderToString(bytes.NewReader(src))
+	if err != nil {
+		t.Fatalf("ReaderToString() error = %v", err)
+	}
+	if want := strings.Repeat("黄昏よりも昏きもの\n", 1000); got != want {
+		t.Errorf("ReaderToString() = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkDecoder_ByteToString(b *testing.B) {
+	d, _ := NewDecoder("shift_jis")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = d.ByteToString(sjisBytes)
+	}
+}
+
+func BenchmarkDecoder_ByteToStringNoPool(b *testing.B) {
+	d, _ := NewDecoder("shift_jis")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = transform.Bytes(d.GetEncoding().NewDecoder(), sjisBytes)
+	}
+}
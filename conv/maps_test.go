@@ -0,0 +1,39 @@
+// Package conv
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package conv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortedKeys(t *testing.T) {
+	ints := map[int]bool{3: true, -1: false, 10: true, 0: false}
+	if got, want := SortedKeys(ints), []int{-1, 0, 3, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %v, want %v", got, want)
+	}
+
+	strs := map[string]int{"b": 1, "a": 2, "B": 3, "": 4}
+	if got, want := SortedKeys(strs), []string{"", "B", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %v, want %v", got, want)
+	}
+
+	if got := SortedKeys(map[string]int(nil)); len(got) != 0 {
+		t.Errorf("SortedKeys() = %v, want empty", got)
+	}
+}
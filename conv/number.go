@@ -0,0 +1,34 @@
+// Package conv
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package conv
+
+import (
+	"strconv"
+)
+
+// AppendInt appends the decimal form of n to dst and returns the extended slice,
+// e.g. to append a number to mem.FakeIO.AvailableBuffer() without a temporary string.
+// It is strconv.AppendInt in base 10.
+func AppendInt(dst []byte, n int64) []byte {
+	return strconv.AppendInt(dst, n, 10)
+}
+
+// AppendUint is like AppendInt but n is an unsigned integer.
+func AppendUint(dst []byte, n uint64) []byte {
+	return strconv.AppendUint(dst, n, 10)
+}
@@ -0,0 +1,72 @@
+// Package conv
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package conv
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestAppendInt(t *testing.T) {
+	tests := []int64{
+		0, 1, 9, 10, 42, 99, 100, 101, 999, 12345,
+		-1, -9, -10, -99, -100,
+		math.MaxInt32, math.MinInt32, math.MaxInt64, math.MinInt64,
+	}
+	for _, n := range tests {
+		t.Run(strconv.FormatInt(n, 10), func(t *testing.T) {
+			prefix := []byte("n=")
+			if got, want := string(AppendInt(prefix, n)), "n="+strconv.FormatInt(n, 10); got != want {
+				t.Errorf("AppendInt() = %v, want %v", got, want)
+			}
+		})
+	}
+	for i := int64(-1000); i <= 1000; i++ {
+		if got := string(AppendInt(nil, i)); got != strconv.FormatInt(i, 10) {
+			t.Fatalf("AppendInt() = %v, want %v", got, i)
+		}
+	}
+}
+
+func TestAppendUint(t *testing.T) {
+	tests := []uint64{0, 7, 10, 99, 100, math.MaxUint32, math.MaxUint64}
+	for _, n := range tests {
+		t.Run(strconv.FormatUint(n, 10), func(t *testing.T) {
+			if got, want := string(AppendUint(nil, n)), strconv.FormatUint(n, 10); got != want {
+				t.Errorf("AppendUint() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkAppendInt(b *testing.B) {
+	dst := make([]byte, 0, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dst = AppendInt(dst[:0], int64(i%100))
+	}
+}
+
+func BenchmarkStrconvAppendInt(b *testing.B) {
+	dst := make([]byte, 0, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dst = strconv.AppendInt(dst[:0], int64(i%100), 10)
+	}
+}
@@ -0,0 +1,241 @@
+// Package conv
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseBoolExt returns the boolean value represented by the string, case-insensitively.
+// It accepts 1, t, true, yes, y, on and 0, f, false, no, n, off.
+// Any other value returns a *strconv.NumError.
+func ParseBoolExt(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "1", "t", "true", "yes", "y", "on":
+		return true, nil
+	case "0", "f", "false", "no", "n", "off":
+		return false, nil
+	}
+	return false, &strconv.NumError{Func: "ParseBoolExt", Num: s, Err: strconv.ErrSyntax}
+}
+
+var (
+	binaryByteUnits  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	decimalByteUnits = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+)
+
+// HumanizeBytes formats the byte count n with the binary (1024) units, e.g. "10.5 MiB".
+func HumanizeBytes(n int64) string {
+	return humanizeBytes(n, 1024, binaryByteUnits)
+}
+
+// HumanizeBytesDecimal formats the byte count n with the decimal (1000) units, e.g. "10.5 MB".
+func HumanizeBytesDecimal(n int64) string {
+	return humanizeBytes(n, 1000, decimalByteUnits)
+}
+
+func humanizeBytes(n int64, base float64, units []string) string {
+	sign := ""
+	v := float64(n)
+	if n < 0 {
+		sign = "-"
+		v = -v
+	}
+	if v < base {
+		return sign + strconv.FormatInt(int64(v), 10) + " " + units[0]
+	}
+	i := 0
+	for v >= base && i < len(units)-1 {
+		v /= base
+		i++
+	}
+	// v rounded to one decimal may reach base, e.g. 1048575 is 1023.999 KiB
+	if v >= base-0.05 && i < len(units)-1 {
+		v /= base
+		i++
+	}
+	return sign + strconv.FormatFloat(v, 'f', 1, 64) + " " + units[i]
+}
+
+// ParseBytes parses a byte size like "10MiB", "1.5 GB" or "512" (bytes) into a byte count.
+// The unit is case-insensitive, the binary units (KiB, MiB, ...) are multiples of 1024
+// and the decimal units (kB, MB, ...) are multiples of 1000.
+func ParseBytes(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	num, unit := str, ""
+	if i >= 0 {
+		num, unit = str[:i], strings.TrimSpace(str[i:])
+	}
+
+	mul := float64(1)
+	if unit != "" && !strings.EqualFold(unit, "B") {
+		mul = 0
+		for exp, u := range binaryByteUnits[1:] {
+			if strings.EqualFold(unit, u) {
+				mul = math.Pow(1024, float64(exp+1))
+				break
+			}
+			if strings.EqualFold(unit, decimalByteUnits[exp+1]) {
+				mul = math.Pow(1000, float64(exp+1))
+				break
+			}
+		}
+		if mul == 0 {
+			return 0, &strconv.NumError{Func: "ParseBytes", Num: s, Err: strconv.ErrSyntax}
+		}
+	}
+
+	if mul == 1 {
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return 0, &strconv.NumError{Func: "ParseBytes", Num: s, Err: err.(*strconv.NumError).Err}
+		}
+		return n, nil
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseBytes", Num: s, Err: err.(*strconv.NumError).Err}
+	}
+	v *= mul
+	if v >= 1<<63 || v < -(1<<63) {
+		return 0, &strconv.NumError{Func: "ParseBytes", Num: s, Err: strconv.ErrRange}
+	}
+	return int64(v), nil
+}
+
+// ParseIntLocalized is like strconv.ParseInt(s, 10, 64), but removes the grouping
+// separator sep first, e.g. "1,234,567" with ',' or "1 234 567" with ' '.
+func ParseIntLocalized(s string, sep rune) (int64, error) {
+	n, err := strconv.ParseInt(strings.ReplaceAll(s, string(sep), ""), 10, 64)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseIntLocalized", Num: s, Err: err.(*strconv.NumError).Err}
+	}
+	return n, nil
+}
+
+// ParseFloatLocalized is like strconv.ParseFloat(s, 64), but removes the grouping
+// separator sep and reads decimal as the decimal point, e.g. "1.234.567,89" with '.' and ','.
+// If decimal is 0 or '.', the decimal point is not replaced.
+func ParseFloatLocalized(s string, sep, decimal rune) (float64, error) {
+	str := strings.ReplaceAll(s, string(sep), "")
+	if decimal != 0 && decimal != '.' {
+		if strings.ContainsRune(str, '.') {
+			return 0, &strconv.NumError{Func: "ParseFloatLocalized", Num: s, Err: strconv.ErrSyntax}
+		}
+		str = strings.Replace(str, string(decimal), ".", 1)
+	}
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseFloatLocalized", Num: s, Err: err.(*strconv.NumError).Err}
+	}
+	return v, nil
+}
+
+// Errors wrapped in the *strconv.NumError returned by ParseFloatStrict and ParseFloatPlain.
+var (
+	ErrNotFinite = errors.New("value is not finite")
+	ErrExponent  = errors.New("exponent notation is not allowed")
+)
+
+// ParseFloatStrict is like strconv.ParseFloat, but only accepts the finite decimal numbers,
+// e.g. for amounts: "Inf" and "NaN" return ErrNotFinite, the hexadecimal floats return
+// strconv.ErrSyntax and the out of range values like "1e309" return strconv.ErrRange.
+func ParseFloatStrict(s string, bitSize int) (float64, error) {
+	return parseFloatStrict("ParseFloatStrict", s, bitSize, true)
+}
+
+// ParseFloatPlain is like ParseFloatStrict, but also rejects the exponent notation
+// like "1e3" with ErrExponent, so s must be written as "1000".
+func ParseFloatPlain(s string, bitSize int) (float64, error) {
+	return parseFloatStrict("ParseFloatPlain", s, bitSize, false)
+}
+
+func parseFloatStrict(fn, s string, bitSize int, exponent bool) (float64, error) {
+	if strings.ContainsAny(s, "xX") {
+		return 0, &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrSyntax}
+	}
+	v, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		return 0, &strconv.NumError{Func: fn, Num: s, Err: err.(*strconv.NumError).Err}
+	}
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, &strconv.NumError{Func: fn, Num: s, Err: ErrNotFinite}
+	}
+	if !exponent && strings.ContainsAny(s, "eE") {
+		return 0, &strconv.NumError{Func: fn, Num: s, Err: ErrExponent}
+	}
+	return v, nil
+}
+
+// ParseKeyValue parses a "k=v;k2=v2" string (with '=' as kvSep and ';' as pairSep)
+// into a map. The keys and values are trimmed of spaces and the empty pairs are skipped.
+// A value enclosed in double quotes may contain the separators and is unquoted as a
+// Go string literal, e.g. `password="a;b=c"`. An empty s returns an empty map.
+// A pair without kvSep, or an unterminated quote, returns an error.
+func ParseKeyValue(s string, pairSep, kvSep rune) (map[string]string, error) {
+	res := make(map[string]string)
+	start, quoted, escaped := 0, false, false
+	// a pairSep is appended to end the last pair
+	for i, r := range s + string(pairSep) {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == pairSep && !quoted:
+			if err := parseKeyValuePair(res, s[start:i], kvSep); err != nil {
+				return nil, err
+			}
+			start = i + utf8.RuneLen(pairSep)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("conv.ParseKeyValue: unterminated quote in %q", s[start:])
+	}
+	return res, nil
+}
+
+func parseKeyValuePair(res map[string]string, pair string, kvSep rune) error {
+	if strings.TrimSpace(pair) == "" {
+		return nil
+	}
+	k, v, ok := strings.Cut(pair, string(kvSep))
+	if !ok {
+		return fmt.Errorf("conv.ParseKeyValue: missing separator %q in pair %q", kvSep, pair)
+	}
+	k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+	if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+		uq, err := strconv.Unquote(v)
+		if err != nil {
+			return fmt.Errorf("conv.ParseKeyValue: invalid quoted value %s: %w", v, err)
+		}
+		v = uq
+	}
+	res[k] = v
+	return nil
+}
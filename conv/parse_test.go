@@ -0,0 +1,302 @@
+// Package conv
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package conv
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseBoolExt(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    bool
+		wantErr bool
+	}{
+		{name: "1", input: "1", want: true},
+		{name: "t", input: "t", want: true},
+		{name: "T", input: "T", want: true},
+		{name: "true", input: "true", want: true},
+		{name: "TRUE", input: "TRUE", want: true},
+		{name: "yes", input: "yes", want: true},
+		{name: "Yes", input: "Yes", want: true},
+		{name: "y", input: "y", want: true},
+		{name: "on", input: "on", want: true},
+		{name: "ON", input: "ON", want: true},
+		{name: "0", input: "0", want: false},
+		{name: "f", input: "f", want: false},
+		{name: "false", input: "false", want: false},
+		{name: "False", input: "False", want: false},
+		{name: "no", input: "no", want: false},
+		{name: "N", input: "N", want: false},
+		{name: "off", input: "off", want: false},
+		{name: "Off", input: "Off", want: false},
+		{name: "empty", input: "", wantErr: true},
+		{name: "space", input: " yes", wantErr: true},
+		{name: "unknown", input: "enabled", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBoolExt(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBoolExt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseBoolExt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHumanizeBytes(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           int64
+		wantBinary  string
+		wantDecimal string
+	}{
+		{name: "zero", n: 0, wantBinary: "0 B", wantDecimal: "0 B"},
+		{name: "bytes", n: 999, wantBinary: "999 B", wantDecimal: "999 B"},
+		{name: "decimal boundary", n: 1000, wantBinary: "1000 B", wantDecimal: "1.0 kB"},
+		{name: "binary boundary", n: 1024, wantBinary: "1.0 KiB", wantDecimal: "1.0 kB"},
+		{name: "round up to mega", n: 1048575, wantBinary: "1.0 MiB", wantDecimal: "1.0 MB"},
+		{name: "round up decimal", n: 999999, wantBinary: "976.6 KiB", wantDecimal: "1.0 MB"},
+		{name: "mega", n: 11010048, wantBinary: "10.5 MiB", wantDecimal: "11.0 MB"},
+		{name: "giga", n: 1 << 30, wantBinary: "1.0 GiB", wantDecimal: "1.1 GB"},
+		{name: "negative", n: -2048, wantBinary: "-2.0 KiB", wantDecimal: "-2.0 kB"},
+		{name: "max", n: 1<<63 - 1, wantBinary: "8.0 EiB", wantDecimal: "9.2 EB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HumanizeBytes(tt.n); got != tt.wantBinary {
+				t.Errorf("HumanizeBytes() = %v, want %v", got, tt.wantBinary)
+			}
+			if got := HumanizeBytesDecimal(tt.n); got != tt.wantDecimal {
+				t.Errorf("HumanizeBytesDecimal() = %v, want %v", got, tt.wantDecimal)
+			}
+		})
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "bytes", input: "512", want: 512},
+		{name: "B", input: "512B", want: 512},
+		{name: "KiB", input: "1KiB", want: 1024},
+		{name: "kB", input: "1kB", want: 1000},
+		{name: "MiB", input: "10MiB", want: 10 << 20},
+		{name: "MB", input: "10MB", want: 10000000},
+		{name: "lower case", input: "10mib", want: 10 << 20},
+		{name: "space", input: "1.5 GiB", want: 3 << 29},
+		{name: "humanized", input: "10.5 MiB", want: 11010048},
+		{name: "EiB", input: "7EiB", want: 7 << 60},
+		{name: "overflow", input: "8EiB", wantErr: true},
+		{name: "unknown unit", input: "10XB", wantErr: true},
+		{name: "no number", input: "MiB", wantErr: true},
+		{name: "fraction bytes", input: "1.5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBytes(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBytes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIntLocalized(t *testing.T) {
+	type args struct {
+		s   string
+		sep rune
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr bool
+	}{
+		{name: "comma", args: args{s: "1,234,567", sep: ','}, want: 1234567},
+		{name: "space", args: args{s: "1 234 567", sep: ' '}, want: 1234567},
+		{name: "no-break space", args: args{s: "-1\u00a0234", sep: '\u00a0'}, want: -1234},
+		{name: "no separator", args: args{s: "42", sep: ','}, want: 42},
+		{name: "other separator", args: args{s: "1.234", sep: ','}, wantErr: true},
+		{name: "empty", args: args{s: ",", sep: ','}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIntLocalized(tt.args.s, tt.args.sep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseIntLocalized() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseIntLocalized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFloatLocalized(t *testing.T) {
+	type args struct {
+		s       string
+		sep     rune
+		decimal rune
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    float64
+		wantErr bool
+	}{
+		{name: "comma grouping", args: args{s: "1,234,567.5", sep: ','}, want: 1234567.5},
+		{name: "space grouping", args: args{s: "1 234,25", sep: ' ', decimal: ','}, want: 1234.25},
+		{name: "european", args: args{s: "1.234.567,89", sep: '.', decimal: ','}, want: 1234567.89},
+		{name: "decimal comma only", args: args{s: "3,5", sep: ' ', decimal: ','}, want: 3.5},
+		{name: "mixed decimal point", args: args{s: "1.5,5", sep: ' ', decimal: ','}, wantErr: true},
+		{name: "two decimals", args: args{s: "1,5,5", sep: ' ', decimal: ','}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFloatLocalized(tt.args.s, tt.args.sep, tt.args.decimal)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFloatLocalized() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseFloatLocalized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFloatStrict(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		bitSize   int
+		want      float64
+		wantErr   error
+		wantPlain error
+	}{
+		{name: "decimal", s: "-1234.56", bitSize: 64, want: -1234.56},
+		{name: "integer", s: "42", bitSize: 64, want: 42},
+		{name: "exponent", s: "1.5e3", bitSize: 64, want: 1500, wantPlain: ErrExponent},
+		{name: "inf", s: "Inf", bitSize: 64, wantErr: ErrNotFinite, wantPlain: ErrNotFinite},
+		{name: "signed infinity", s: "-infinity", bitSize: 64, wantErr: ErrNotFinite, wantPlain: ErrNotFinite},
+		{name: "nan", s: "NaN", bitSize: 64, wantErr: ErrNotFinite, wantPlain: ErrNotFinite},
+		{name: "overflow", s: "1e309", bitSize: 64, wantErr: strconv.ErrRange, wantPlain: strconv.ErrRange},
+		{name: "overflow float32", s: "1e39", bitSize: 32, wantErr: strconv.ErrRange, wantPlain: strconv.ErrRange},
+		{name: "hexadecimal", s: "0x1p-2", bitSize: 64, wantErr: strconv.ErrSyntax, wantPlain: strconv.ErrSyntax},
+		{name: "invalid", s: "1.2.3", bitSize: 64, wantErr: strconv.ErrSyntax, wantPlain: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFloatStrict(tt.s, tt.bitSize)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("ParseFloatStrict() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+			got, err = ParseFloatPlain(tt.s, tt.bitSize)
+			if tt.wantPlain != nil {
+				tt.want = 0
+			}
+			if !errors.Is(err, tt.wantPlain) || got != tt.want {
+				t.Errorf("ParseFloatPlain() = %v, %v, want %v, %v", got, err, tt.want, tt.wantPlain)
+			}
+		})
+	}
+}
+
+func TestParseKeyValue(t *testing.T) {
+	type args struct {
+		s       string
+		pairSep rune
+		kvSep   rune
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "connection string",
+			args: args{s: "host=localhost; port = 5432 ;user=admin", pairSep: ';', kvSep: '='},
+			want: map[string]string{"host": "localhost", "port": "5432", "user": "admin"},
+		},
+		{
+			name: "quoted values",
+			args: args{s: `password="a;b=c"; name="say \"hi\""; empty=""`, pairSep: ';', kvSep: '='},
+			want: map[string]string{"password": "a;b=c", "name": `say "hi"`, "empty": ""},
+		},
+		{
+			name: "value with kv separator",
+			args: args{s: "q=a=b&x=", pairSep: '&', kvSep: '='},
+			want: map[string]string{"q": "a=b", "x": ""},
+		},
+		{
+			name: "empty pairs",
+			args: args{s: ";a:1;;b:2;", pairSep: ';', kvSep: ':'},
+			want: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name: "multibyte separators",
+			args: args{s: "名前：太郎、年齢：20", pairSep: '、', kvSep: '：'},
+			want: map[string]string{"名前": "太郎", "年齢": "20"},
+		},
+		{
+			name: "empty",
+			args: args{s: "", pairSep: ';', kvSep: '='},
+			want: map[string]string{},
+		},
+		{
+			name:    "missing separator",
+			args:    args{s: "host=localhost;port", pairSep: ';', kvSep: '='},
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quote",
+			args:    args{s: `a="b;c=d`, pairSep: ';', kvSep: '='},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKeyValue(tt.args.s, tt.args.pairSep, tt.args.kvSep)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeyValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseKeyValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ package conv
 import (
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 func CamelToSnake(s string) string {
@@ -71,3 +72,262 @@ func SnakeToCamel(s string) string {
 	}
 	return snake.String()
 }
+
+// SnakeToKebab converts a snake_case identifier to kebab-case by replacing each '_' with '-',
+// the leading and trailing delimiters are kept, so KebabToSnake restores s if it has no '-'.
+func SnakeToKebab(s string) string { return strings.ReplaceAll(s, "_", "-") }
+
+// KebabToSnake converts a kebab-case identifier to snake_case by replacing each '-' with '_',
+// the leading and trailing delimiters are kept, so SnakeToKebab restores s if it has no '_'.
+func KebabToSnake(s string) string { return strings.ReplaceAll(s, "-", "_") }
+
+// NormalizeNewlines converts the "\r\n" (Windows) and lone "\r" (old Mac) line endings of s to "\n".
+func NormalizeNewlines(s string) string { return replaceNewlines(s, "\n") }
+
+// ToCRLF converts all the line endings of s ("\n", "\r\n" or a lone "\r") to "\r\n",
+// e.g. to write a Windows text file. An existing "\r\n" is not doubled.
+func ToCRLF(s string) string { return replaceNewlines(s, "\r\n") }
+
+// replaceNewlines replaces each line ending of s with newline in a single pass.
+func replaceNewlines(s, newline string) string {
+	i := strings.IndexAny(s, "\r\n")
+	if i < 0 {
+		return s
+	}
+
+	res := new(strings.Builder)
+	res.Grow(len(s) + strings.Count(s, "\n")*(len(newline)-1))
+	for i >= 0 {
+		res.WriteString(s[:i])
+		res.WriteString(newline)
+		if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+			i++
+		}
+		s = s[i+1:]
+		i = strings.IndexAny(s, "\r\n")
+	}
+	res.WriteString(s)
+	return res.String()
+}
+
+// Indent adds prefix at the beginning of each non-empty line of s,
+// the empty lines are left as is to not produce trailing whitespace.
+// The line endings of s, including the trailing newline, are preserved.
+func Indent(s, prefix string) string {
+	if s == "" || prefix == "" {
+		return s
+	}
+
+	res := new(strings.Builder)
+	res.Grow(len(s) + len(prefix)*(strings.Count(s, "\n")+1))
+	for s != "" {
+		line := s
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			line = s[:i+1]
+		}
+		if line != "\n" && line != "\r\n" {
+			res.WriteString(prefix)
+		}
+		res.WriteString(line)
+		s = s[len(line):]
+	}
+	return res.String()
+}
+
+// Dedent removes the longest common leading whitespace (spaces and tabs) of the
+// non-blank lines of s, a tab and a space are not considered equal.
+// The lines made of whitespace only are emptied, the line endings are preserved.
+func Dedent(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	margin, first := "", true
+	for _, line := range lines {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if isBlankLine(line[len(indent):]) {
+			continue
+		}
+		if first {
+			margin, first = indent, false
+			continue
+		}
+		i := 0
+		for i < len(margin) && i < len(indent) && margin[i] == indent[i] {
+			i++
+		}
+		margin = margin[:i]
+	}
+
+	res := new(strings.Builder)
+	res.Grow(len(s))
+	for _, line := range lines {
+		rest := strings.TrimLeft(line, " \t")
+		if isBlankLine(rest) {
+			res.WriteString(rest)
+			continue
+		}
+		res.WriteString(line[len(margin):])
+	}
+	return res.String()
+}
+
+// isBlankLine reports whether the rest of a line is only its line ending.
+func isBlankLine(rest string) bool { return rest == "" || rest == "\n" || rest == "\r\n" }
+
+// ExpandTabs replaces each tab of s with the spaces needed to reach the next multiple
+// of tabWidth columns, the column is counted in runes and restarts after each newline.
+// A tabWidth less than 1 uses 8.
+func ExpandTabs(s string, tabWidth int) string {
+	if strings.IndexByte(s, '\t') < 0 {
+		return s
+	}
+	if tabWidth < 1 {
+		tabWidth = 8
+	}
+
+	res := new(strings.Builder)
+	res.Grow(len(s) + tabWidth*strings.Count(s, "\t"))
+	col := 0
+	for _, r := range s {
+		switch r {
+		case '\t':
+			n := tabWidth - col%tabWidth
+			res.WriteString(strings.Repeat(" ", n))
+			col += n
+		case '\n':
+			res.WriteByte('\n')
+			col = 0
+		default:
+			res.WriteRune(r)
+			col++
+		}
+	}
+	return res.String()
+}
+
+// Capitalize upper-cases the first rune of s and leaves the rest unchanged, e.g. "userID" to "UserID".
+func Capitalize(s string) string { return mapFirstRune(s, unicode.ToUpper) }
+
+// Uncapitalize lower-cases the first rune of s and leaves the rest unchanged, e.g. "UserID" to "userID".
+func Uncapitalize(s string) string { return mapFirstRune(s, unicode.ToLower) }
+
+func mapFirstRune(s string, mapping func(rune) rune) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || (r == utf8.RuneError && size == 1) {
+		return s
+	}
+	m := mapping(r)
+	if m == r {
+		return s
+	}
+	return string(m) + s[size:]
+}
+
+// ToTitle upper-cases the first letter of each word of s and lower-cases the rest,
+// a word is a run of letters and digits so "o'brien" becomes "O'Brien".
+// The underscores are replaced by spaces to make a label of a snake_case identifier,
+// "user_id" becomes "User Id", while the hyphens are kept ("jean-paul" becomes "Jean-Paul"),
+// so a kebab-case identifier should be converted by KebabToSnake first.
+// Unlike the deprecated strings.Title, the letters after the first are lower-cased.
+func ToTitle(s string) string { return toTitle(s, false) }
+
+// ToTitleKeepAcronyms is like ToTitle but the words written in upper case only
+// (e.g. "NASA") are kept as is.
+func ToTitleKeepAcronyms(s string) string { return toTitle(s, true) }
+
+func toTitle(s string, keepAcronyms bool) string {
+	if s == "" {
+		return s
+	}
+
+	title := new(strings.Builder)
+	title.Grow(len(s))
+	for s != "" {
+		end := strings.IndexFunc(s, isNotWordRune)
+		if end < 0 {
+			end = len(s)
+		}
+		if end == 0 {
+			r, size := utf8.DecodeRuneInString(s)
+			if r == '_' {
+				r = ' '
+			}
+			title.WriteRune(r)
+			s = s[size:]
+			continue
+		}
+
+		word := s[:end]
+		if keepAcronyms && isAcronym(word) {
+			title.WriteString(word)
+		} else {
+			for i, r := range word {
+				if i == 0 {
+					title.WriteRune(unicode.ToTitle(r))
+				} else {
+					title.WriteRune(unicode.ToLower(r))
+				}
+			}
+		}
+		s = s[end:]
+	}
+	return title.String()
+}
+
+func isNotWordRune(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+
+// isAcronym reports whether word has at least two letters and no lower case letter.
+func isAcronym(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters > 1
+}
+
+// Coalesce returns the first non-empty string of vals, or "" if all are empty.
+func Coalesce(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// DefaultString returns s, or def if s is empty.
+func DefaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// JoinNonEmpty is like strings.Join but the empty parts are omitted,
+// so no double separator is produced ("a,,b" becomes "a,b").
+func JoinNonEmpty(sep string, parts ...string) string {
+	return joinFunc(sep, parts, func(s string) bool { return s != "" })
+}
+
+// JoinNonBlank is like JoinNonEmpty but the parts made of white space only are omitted too,
+// the kept parts are not trimmed.
+func JoinNonBlank(sep string, parts ...string) string {
+	return joinFunc(sep, parts, func(s string) bool { return strings.TrimSpace(s) != "" })
+}
+
+func joinFunc(sep string, parts []string, keep func(string) bool) string {
+	b := new(strings.Builder)
+	for _, p := range parts {
+		if !keep(p) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
@@ -70,3 +70,237 @@ func TestSnakeToCamel(t *testing.T) {
 		})
 	}
 }
+
+func TestSnakeToKebab(t *testing.T) {
+	tests := []struct {
+		name  string
+		snake string
+		kebab string
+	}{
+		{name: "words", snake: "as_bs_cs", kebab: "as-bs-cs"},
+		{name: "leading", snake: "_as_bs", kebab: "-as-bs"},
+		{name: "trailing", snake: "as_bs_", kebab: "as-bs-"},
+		{name: "double", snake: "as__bs", kebab: "as--bs"},
+		{name: "acronym", snake: "user_ID_list", kebab: "user-ID-list"},
+		{name: "empty", snake: "", kebab: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SnakeToKebab(tt.snake); got != tt.kebab {
+				t.Errorf("SnakeToKebab() = %v, want %v", got, tt.kebab)
+			}
+			if got := KebabToSnake(tt.kebab); got != tt.snake {
+				t.Errorf("KebabToSnake() = %v, want %v", got, tt.snake)
+			}
+		})
+	}
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantLF   string
+		wantCRLF string
+	}{
+		{name: "mixed", input: "a\r\nb\rc\nd", wantLF: "a\nb\nc\nd", wantCRLF: "a\r\nb\r\nc\r\nd"},
+		{name: "trailing", input: "a\r\n", wantLF: "a\n", wantCRLF: "a\r\n"},
+		{name: "trailing cr", input: "a\r", wantLF: "a\n", wantCRLF: "a\r\n"},
+		{name: "cr before crlf", input: "a\r\r\nb", wantLF: "a\n\nb", wantCRLF: "a\r\n\r\nb"},
+		{name: "lf cr", input: "a\n\rb", wantLF: "a\n\nb", wantCRLF: "a\r\n\r\nb"},
+		{name: "no newline", input: "abc", wantLF: "abc", wantCRLF: "abc"},
+		{name: "empty", input: "", wantLF: "", wantCRLF: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeNewlines(tt.input); got != tt.wantLF {
+				t.Errorf("NormalizeNewlines() = %q, want %q", got, tt.wantLF)
+			}
+			if got := ToCRLF(tt.input); got != tt.wantCRLF {
+				t.Errorf("ToCRLF() = %q, want %q", got, tt.wantCRLF)
+			}
+		})
+	}
+}
+
+func TestIndent(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		prefix string
+		want   string
+	}{
+		{name: "trailing newline", input: "a\nb\n", prefix: "  ", want: "  a\n  b\n"},
+		{name: "no trailing newline", input: "a\nb", prefix: "\t", want: "\ta\n\tb"},
+		{name: "blank lines", input: "a\n\nb\r\n\r\nc", prefix: "> ", want: "> a\n\n> b\r\n\r\n> c"},
+		{name: "empty", input: "", prefix: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Indent(tt.input, tt.prefix); got != tt.want {
+				t.Errorf("Indent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "common spaces", input: "    a\n      b\n    c\n", want: "a\n  b\nc\n"},
+		{name: "blank lines", input: "  a\n\n   \n  b", want: "a\n\n\nb"},
+		{name: "mixed indentation", input: "\t  a\n\t b\n", want: " a\nb\n"},
+		{name: "tab and spaces", input: "\ta\n  b\n", want: "\ta\n  b\n"},
+		{name: "crlf", input: "  a\r\n  \r\n    b\r\n", want: "a\r\n\r\n  b\r\n"},
+		{name: "no indent", input: "a\n  b", want: "a\n  b"},
+		{name: "empty", input: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Dedent(tt.input); got != tt.want {
+				t.Errorf("Dedent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTabs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		tabWidth int
+		want     string
+	}{
+		{name: "leading", input: "\ta", tabWidth: 4, want: "    a"},
+		{name: "mid stop", input: "ab\tc", tabWidth: 4, want: "ab  c"},
+		{name: "at stop", input: "abcd\te", tabWidth: 4, want: "abcd    e"},
+		{name: "consecutive", input: "a\t\tb", tabWidth: 4, want: "a       b"},
+		{name: "multiple lines", input: "a\tb\nabc\td\n\te", tabWidth: 4, want: "a   b\nabc d\n    e"},
+		{name: "runes", input: "\u65e5\u672c\tx", tabWidth: 4, want: "\u65e5\u672c  x"},
+		{name: "default width", input: "a\tb", tabWidth: 0, want: "a       b"},
+		{name: "no tab", input: "abc", tabWidth: 4, want: "abc"},
+		{name: "empty", input: "", tabWidth: 4, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandTabs(tt.input, tt.tabWidth); got != tt.want {
+				t.Errorf("ExpandTabs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapitalize(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		capitalize   string
+		uncapitalize string
+	}{
+		{name: "ascii", input: "userID", capitalize: "UserID", uncapitalize: "userID"},
+		{name: "upper", input: "UserID", capitalize: "UserID", uncapitalize: "userID"},
+		{name: "multibyte", input: "élan Vital", capitalize: "Élan Vital", uncapitalize: "élan Vital"},
+		{name: "multibyte upper", input: "Ωmega", capitalize: "Ωmega", uncapitalize: "ωmega"},
+		{name: "no case", input: "日本", capitalize: "日本", uncapitalize: "日本"},
+		{name: "invalid utf8", input: "\xffabc", capitalize: "\xffabc", uncapitalize: "\xffabc"},
+		{name: "empty", input: "", capitalize: "", uncapitalize: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Capitalize(tt.input); got != tt.capitalize {
+				t.Errorf("Capitalize() = %q, want %q", got, tt.capitalize)
+			}
+			if got := Uncapitalize(tt.input); got != tt.uncapitalize {
+				t.Errorf("Uncapitalize() = %q, want %q", got, tt.uncapitalize)
+			}
+		})
+	}
+}
+
+func TestToTitle(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		want         string
+		wantAcronyms string
+	}{
+		{name: "words", input: "hello world", want: "Hello World", wantAcronyms: "Hello World"},
+		{name: "apostrophe", input: "o'brien", want: "O'Brien", wantAcronyms: "O'Brien"},
+		{name: "acronym", input: "NASA report", want: "Nasa Report", wantAcronyms: "NASA Report"},
+		{name: "mixed case", input: "hELLO wORLD", want: "Hello World", wantAcronyms: "Hello World"},
+		{name: "snake case", input: "user_id  list-2", want: "User Id  List-2", wantAcronyms: "User Id  List-2"},
+		{name: "snake case acronym", input: "user_ID_list", want: "User Id List", wantAcronyms: "User ID List"},
+		{name: "kebab case", input: KebabToSnake("jean-paul"), want: "Jean Paul", wantAcronyms: "Jean Paul"},
+		{name: "hyphen", input: "jean-paul", want: "Jean-Paul", wantAcronyms: "Jean-Paul"},
+		{name: "single letter", input: "a B", want: "A B", wantAcronyms: "A B"},
+		{name: "unicode", input: "élan ÉCOLE", want: "Élan École", wantAcronyms: "Élan ÉCOLE"},
+		{name: "empty", input: "", want: "", wantAcronyms: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToTitle(tt.input); got != tt.want {
+				t.Errorf("ToTitle() = %v, want %v", got, tt.want)
+			}
+			if got := ToTitleKeepAcronyms(tt.input); got != tt.wantAcronyms {
+				t.Errorf("ToTitleKeepAcronyms() = %v, want %v", got, tt.wantAcronyms)
+			}
+		})
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []string
+		want string
+	}{
+		{name: "first", vals: []string{"a", "b"}, want: "a"},
+		{name: "skip empty", vals: []string{"", "", "c"}, want: "c"},
+		{name: "all empty", vals: []string{"", ""}, want: ""},
+		{name: "no input", vals: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Coalesce(tt.vals...); got != tt.want {
+				t.Errorf("Coalesce() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultString(t *testing.T) {
+	if got := DefaultString("", "def"); got != "def" {
+		t.Errorf("DefaultString() = %v, want %v", got, "def")
+	}
+	if got := DefaultString("val", "def"); got != "val" {
+		t.Errorf("DefaultString() = %v, want %v", got, "val")
+	}
+}
+
+func TestJoinNonEmpty(t *testing.T) {
+	tests := []struct {
+		name      string
+		parts     []string
+		want      string
+		wantBlank string
+	}{
+		{name: "interior", parts: []string{"a", "", "b"}, want: "a,b", wantBlank: "a,b"},
+		{name: "leading and trailing", parts: []string{"", "a", "b", ""}, want: "a,b", wantBlank: "a,b"},
+		{name: "whitespace", parts: []string{"a", " ", "\t", " b "}, want: "a, ,\t, b ", wantBlank: "a, b "},
+		{name: "all empty", parts: []string{"", ""}, want: "", wantBlank: ""},
+		{name: "no parts", parts: nil, want: "", wantBlank: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JoinNonEmpty(",", tt.parts...); got != tt.want {
+				t.Errorf("JoinNonEmpty() = %q, want %q", got, tt.want)
+			}
+			if got := JoinNonBlank(",", tt.parts...); got != tt.wantBlank {
+				t.Errorf("JoinNonBlank() = %q, want %q", got, tt.wantBlank)
+			}
+		})
+	}
+}
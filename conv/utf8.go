@@ -18,7 +18,19 @@
 package conv
 
 import (
+	"bytes"
+	"strings"
+	"unicode"
 	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Byte order marks stripped by TrimBOM.
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
 )
 
 // CutUnicodeString is like RuneCount but its input is a string.
@@ -74,3 +86,203 @@ func _findUnicodeString(src, find string) (start, end int) {
 	}
 	return
 }
+
+// TrimBOM returns b without a leading UTF-8 or UTF-16 (BE/LE) byte order mark,
+// b is returned unchanged if it has no BOM. The result shares b's storage.
+func TrimBOM(b []byte) []byte {
+	for _, bom := range [][]byte{bomUTF8, bomUTF16BE, bomUTF16LE} {
+		if bytes.HasPrefix(b, bom) {
+			return b[len(bom):]
+		}
+	}
+	return b
+}
+
+// TrimBOMString is like TrimBOM but its input is a string.
+func TrimBOMString(s string) string {
+	for _, bom := range [][]byte{bomUTF8, bomUTF16BE, bomUTF16LE} {
+		if strings.HasPrefix(s, BytesToString(bom)) {
+			return s[len(bom):]
+		}
+	}
+	return s
+}
+
+// EqualFoldNormalized reports whether a and b are equal under Unicode case-folding
+// after both are normalized to NFC, so the composed and decomposed forms of "café" are equal.
+// The case-folding is not locale aware, e.g. the Turkish "İ" is not equal to "i".
+func EqualFoldNormalized(a, b string) bool {
+	return strings.EqualFold(norm.NFC.String(a), norm.NFC.String(b))
+}
+
+// StringInfo returns the rune count, the byte length and whether s is valid UTF-8 in a single pass.
+// Each invalid byte is counted as one rune (utf8.RuneError), same as utf8.RuneCountInString.
+func StringInfo(s string) (runes, size int, validUTF8 bool) {
+	validUTF8 = true
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			i++
+		} else {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				validUTF8 = false
+			}
+			i += size
+		}
+		runes++
+	}
+	return runes, len(s), validUTF8
+}
+
+// SplitRunes slices s into substrings separated by the rune sep, with the same n semantics as strings.SplitN:
+//
+//	n > 0: at most n substrings; the last substring will be the unsplit remainder.
+//	n == 0: the result is nil (zero substrings)
+//	n < 0: all substrings
+//
+// UTF-8 is self-synchronizing, so a matched sep is always a whole rune of s and
+// a multibyte character is never split.
+func SplitRunes(s string, sep rune, n int) []string {
+	return strings.SplitN(s, string(sep), n)
+}
+
+// Mask replaces all but the last keep runes of s with maskRune, e.g. to log a secret.
+// s is returned unchanged if keep is not less than its number of runes,
+// and every rune is masked if keep <= 0.
+func Mask(s string, keep int, maskRune rune) string {
+	n := utf8.RuneCountInString(s)
+	if keep >= n {
+		return s
+	}
+	if keep < 0 {
+		keep = 0
+	}
+
+	masked := n - keep
+	var b strings.Builder
+	b.Grow(masked*utf8.RuneLen(maskRune) + len(s))
+	for i := 0; i < masked; i++ {
+		b.WriteRune(maskRune)
+	}
+	// skip the masked runes of s
+	off := 0
+	for i := 0; i < masked; i++ {
+		_, size := utf8.DecodeRuneInString(s[off:])
+		off += size
+	}
+	b.WriteString(s[off:])
+	return b.String()
+}
+
+// StripControlChars removes the control characters (Unicode category Cc, e.g. NUL, ESC, DEL)
+// from s, except the runes of keep (typically '\t', '\n'). The other runes, including
+// the invalid UTF-8 bytes, are left intact.
+func StripControlChars(s string, keep ...rune) string {
+	strip := func(r rune) bool { return unicode.IsControl(r) && !runeIn(r, keep) }
+	i := strings.IndexFunc(s, strip)
+	if i < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) - 1)
+	b.WriteString(s[:i])
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !strip(r) {
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+func runeIn(r rune, runes []rune) bool {
+	for _, v := range runes {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveDiacritics removes the diacritical marks of s, e.g. "Renée" becomes "Renee" for a search index.
+// s is decomposed to NFD, the nonspacing marks (Unicode category Mn) following a Latin, Greek or
+// Cyrillic letter are dropped and the result is recomposed to NFC. The marks of the other scripts
+// are kept, e.g. the Japanese dakuten of "が" makes another kana than "か", and so are the letters
+// which are not composed with a mark (e.g. "ø", "ß").
+func RemoveDiacritics(s string) string {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return s
+	}
+
+	d := norm.NFD.String(s)
+	res := make([]byte, 0, len(d))
+	strip := false
+	for _, r := range d {
+		if unicode.Is(unicode.Mn, r) {
+			if strip {
+				continue
+			}
+		} else {
+			// the marks are dropped only after a base letter of these scripts
+			strip = unicode.In(r, unicode.Latin, unicode.Greek, unicode.Cyrillic)
+		}
+		res = utf8.AppendRune(res, r)
+	}
+	return norm.NFC.String(BytesToString(res))
+}
+
+// WithinRuneLimit reports whether s has at most max runes (e.g. a "max 140 characters" rule)
+// and returns the rune count. The counting stops once max is exceeded, so for a long s
+// over the limit count is max+1 rather than the full count. A negative max is 0.
+func WithinRuneLimit(s string, max int) (ok bool, count int) {
+	if max < 0 {
+		max = 0
+	}
+	if len(s) <= max {
+		// a rune is at least one byte
+		return true, utf8.RuneCountInString(s)
+	}
+	for i := 0; i < len(s); count++ {
+		if count == max {
+			return false, count + 1
+		}
+		if s[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return true, count
+}
+
+// TrimToValidUTF8 returns the longest prefix of s of at most maxBytes bytes that doesn't
+// cut a rune in the middle, e.g. to fit a byte-limited column. Invalid bytes already in s
+// are kept as is. A negative maxBytes is 0.
+func TrimToValidUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	if maxBytes <= 0 {
+		return ""
+	}
+
+	// back off to the start of the rune holding the first byte cut off
+	start := maxBytes
+	for start > 0 && maxBytes-start < utf8.UTFMax-1 && !utf8.RuneStart(s[start]) {
+		start--
+	}
+	if _, size := utf8.DecodeRuneInString(s[start:]); start+size > maxBytes {
+		return s[:start]
+	}
+	return s[:maxBytes]
+}
@@ -18,6 +18,8 @@
 package conv
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -80,3 +82,247 @@ func TestCutUnicodeString(t *testing.T) {
 		})
 	}
 }
+
+func TestTrimBOM(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "utf8", src: "\xEF\xBB\xBFid,name", want: "id,name"},
+		{name: "utf16 be", src: "\xFE\xFF\x00a", want: "\x00a"},
+		{name: "utf16 le", src: "\xFF\xFEa\x00", want: "a\x00"},
+		{name: "no bom", src: "黄昏よりも昏きもの", want: "黄昏よりも昏きもの"},
+		{name: "bom only once", src: "\xEF\xBB\xBF\xEF\xBB\xBFa", want: "\xEF\xBB\xBFa"},
+		{name: "empty", src: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrimBOM([]byte(tt.src)); string(got) != tt.want {
+				t.Errorf("TrimBOM() = %q, want %q", got, tt.want)
+			}
+			if got := TrimBOMString(tt.src); got != tt.want {
+				t.Errorf("TrimBOMString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualFoldNormalized(t *testing.T) {
+	type args struct {
+		a string
+		b string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{name: "nfc nfd", args: args{a: "caf\u00e9", b: "cafe\u0301"}, want: true},
+		{name: "nfd upper", args: args{a: "CAFE\u0301", b: "caf\u00e9"}, want: true},
+		{name: "accent differs", args: args{a: "cafe", b: "caf\u00e9"}, want: false},
+		{name: "ascii", args: args{a: "Go", b: "GO"}, want: true},
+		{name: "turkish dotted I", args: args{a: "\u0130", b: "i"}, want: false},
+		{name: "turkish dotless i", args: args{a: "\u0131", b: "I"}, want: false},
+		{name: "japanese", args: args{a: "\u304c", b: "\u304b\u3099"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualFoldNormalized(tt.args.a, tt.args.b); got != tt.want {
+				t.Errorf("EqualFoldNormalized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringInfo(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		wantRunes int
+		wantBytes int
+		wantValid bool
+	}{
+		{name: "empty", s: "", wantRunes: 0, wantBytes: 0, wantValid: true},
+		{name: "ascii", s: "abc", wantRunes: 3, wantBytes: 3, wantValid: true},
+		{name: "utf8", s: "あいう", wantRunes: 3, wantBytes: 9, wantValid: true},
+		{name: "invalid byte", s: "a\xffb", wantRunes: 3, wantBytes: 3, wantValid: false},
+		{name: "truncated rune", s: "あ\xe3\x81", wantRunes: 3, wantBytes: 5, wantValid: false},
+		{name: "replacement char", s: "\uFFFD", wantRunes: 1, wantBytes: 3, wantValid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runes, bytes, valid := StringInfo(tt.s)
+			if runes != tt.wantRunes || bytes != tt.wantBytes || valid != tt.wantValid {
+				t.Errorf("StringInfo() = (%d, %d, %v), want (%d, %d, %v)",
+					runes, bytes, valid, tt.wantRunes, tt.wantBytes, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestSplitRunes(t *testing.T) {
+	type args struct {
+		s   string
+		sep rune
+		n   int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{name: "full-width space", args: args{s: "黄昏よりも昏きもの　血の流れより紅きもの　時の流れに", sep: '　', n: -1},
+			want: []string{"黄昏よりも昏きもの", "血の流れより紅きもの", "時の流れに"}},
+		{name: "at most n", args: args{s: "a　b　c　d", sep: '　', n: 2}, want: []string{"a", "b　c　d"}},
+		{name: "zero", args: args{s: "a　b", sep: '　', n: 0}, want: nil},
+		{name: "no separator", args: args{s: "あいう", sep: '、', n: -1}, want: []string{"あいう"}},
+		{name: "share bytes", args: args{s: "あいう", sep: 'い', n: -1}, want: []string{"あ", "う"}},
+		{name: "ascii", args: args{s: "a,b,,c", sep: ',', n: -1}, want: []string{"a", "b", "", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitRunes(tt.args.s, tt.args.sep, tt.args.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitRunes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMask(t *testing.T) {
+	type args struct {
+		s        string
+		keep     int
+		maskRune rune
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "keep last 4", args: args{s: "sk-1234567890", keep: 4, maskRune: '*'}, want: "*********7890"},
+		{name: "multibyte", args: args{s: "パスワード秘密", keep: 2, maskRune: '*'}, want: "*****秘密"},
+		{name: "multibyte mask", args: args{s: "secret", keep: 1, maskRune: '●'}, want: "●●●●●t"},
+		{name: "keep == len", args: args{s: "日本語", keep: 3, maskRune: '*'}, want: "日本語"},
+		{name: "keep > len", args: args{s: "abc", keep: 10, maskRune: '*'}, want: "abc"},
+		{name: "keep 0", args: args{s: "日本語", keep: 0, maskRune: '*'}, want: "***"},
+		{name: "keep negative", args: args{s: "abc", keep: -1, maskRune: '#'}, want: "###"},
+		{name: "empty", args: args{s: "", keep: 0, maskRune: '*'}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mask(tt.args.s, tt.args.keep, tt.args.maskRune); got != tt.want {
+				t.Errorf("Mask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripControlChars(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		keep  []rune
+		want  string
+	}{
+		{name: "nul and esc", input: "a\x00b\x1b[31mc", want: "ab[31mc"},
+		{name: "retained newline", input: "line1\r\nline2\t\x07", keep: []rune{'\n', '\t'}, want: "line1\nline2\t"},
+		{name: "strip newline", input: "line1\nline2", want: "line1line2"},
+		{name: "multibyte", input: "日本\u0085語\x7f", want: "日本語"},
+		{name: "invalid utf8", input: "a\xff\x00b", want: "a\xffb"},
+		{name: "printable", input: "hello, 世界", want: "hello, 世界"},
+		{name: "empty", input: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripControlChars(tt.input, tt.keep...); got != tt.want {
+				t.Errorf("StripControlChars() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveDiacritics(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "french", input: "Renée à l'école", want: "Renee a l'ecole"},
+		{name: "spanish", input: "Español, niño, corazón", want: "Espanol, nino, corazon"},
+		{name: "german", input: "Müller Straße", want: "Muller Straße"},
+		{name: "vietnamese", input: "Tiếng Việt", want: "Tieng Viet"},
+		{name: "decomposed", input: "Rene\u0301e", want: "Renee"},
+		{name: "greek", input: "Ελλάδα", want: "Ελλαδα"},
+		{name: "cyrillic", input: "йёЙ", want: "иеИ"},
+		{name: "japanese dakuten", input: "がぎぐぱ", want: "がぎぐぱ"},
+		{name: "japanese decomposed", input: "\u304b\u3099", want: "\u304c"},
+		{name: "mixed scripts", input: "café がっこう", want: "cafe がっこう"},
+		{name: "ascii", input: "plain ASCII 123", want: "plain ASCII 123"},
+		{name: "empty", input: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveDiacritics(tt.input); got != tt.want {
+				t.Errorf("RemoveDiacritics() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithinRuneLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		max       int
+		wantOK    bool
+		wantCount int
+	}{
+		{name: "ascii within", input: "hello", max: 10, wantOK: true, wantCount: 5},
+		{name: "ascii at limit", input: "hello", max: 5, wantOK: true, wantCount: 5},
+		{name: "ascii over", input: "hello!", max: 5, wantOK: false, wantCount: 6},
+		{name: "multibyte within", input: "こんにちは世界", max: 7, wantOK: true, wantCount: 7},
+		{name: "multibyte over", input: "こんにちは世界", max: 6, wantOK: false, wantCount: 7},
+		{name: "early exit", input: strings.Repeat("あ", 100000), max: 140, wantOK: false, wantCount: 141},
+		{name: "zero max", input: "a", max: 0, wantOK: false, wantCount: 1},
+		{name: "negative max", input: "a", max: -1, wantOK: false, wantCount: 1},
+		{name: "empty", input: "", max: 0, wantOK: true, wantCount: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, count := WithinRuneLimit(tt.input, tt.max)
+			if ok != tt.wantOK || count != tt.wantCount {
+				t.Errorf("WithinRuneLimit() = %v, %d, want %v, %d", ok, count, tt.wantOK, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestTrimToValidUTF8(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxBytes int
+		want     string
+	}{
+		{name: "fits", input: "abc", maxBytes: 3, want: "abc"},
+		{name: "ascii", input: "abcdef", maxBytes: 4, want: "abcd"},
+		{name: "rune boundary", input: "\u65e5\u672c\u8a9e", maxBytes: 6, want: "\u65e5\u672c"},
+		{name: "mid rune 1", input: "\u65e5\u672c\u8a9e", maxBytes: 7, want: "\u65e5\u672c"},
+		{name: "mid rune 2", input: "\u65e5\u672c\u8a9e", maxBytes: 8, want: "\u65e5\u672c"},
+		{name: "first rune", input: "\u65e5\u672c", maxBytes: 2, want: ""},
+		{name: "4 bytes rune", input: "a\U0001F600b", maxBytes: 4, want: "a"},
+		{name: "mixed", input: "a\u00e9b", maxBytes: 2, want: "a"},
+		{name: "invalid kept", input: "a\xff\xffb", maxBytes: 3, want: "a\xff\xff"},
+		{name: "continuation run", input: "\x80\x80\x80\x80\x80", maxBytes: 4, want: "\x80\x80\x80\x80"},
+		{name: "zero", input: "abc", maxBytes: 0, want: ""},
+		{name: "negative", input: "abc", maxBytes: -1, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrimToValidUTF8(tt.input, tt.maxBytes); got != tt.want {
+				t.Errorf("TrimToValidUTF8() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -31,3 +31,119 @@ func UnixtimeToTime(v float64) time.Time {
 	sec, dec := math.Modf(v)
 	return time.Unix(int64(sec), int64(dec*(1e9)))
 }
+
+// FormatUnixtime returns the Unix time v (see UnixtimeToTime) formatted by layout in loc.
+// If layout is empty, time.RFC3339 is used. If loc is nil, time.UTC is used.
+func FormatUnixtime(v float64, layout string, loc *time.Location) string {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return UnixtimeToTime(v).In(loc).Format(layout)
+}
+
+// TruncateTo returns the result of rounding t down to a multiple of unit on the wall clock
+// of t.Location(), e.g. TruncateTo(t, 24*time.Hour) is the local midnight of t.
+// Unlike time.Time.Truncate, the multiples are counted in local time instead of the absolute time.
+// If unit <= 0, TruncateTo returns t stripped of any monotonic clock reading but otherwise unchanged.
+func TruncateTo(t time.Time, unit time.Duration) time.Time {
+	w := wallClock(t)
+	return shiftWallClock(t, w.Truncate(unit).Sub(w))
+}
+
+// RoundTo is like TruncateTo but rounds t to the nearest multiple of unit,
+// the halfway values are rounded up.
+func RoundTo(t time.Time, unit time.Duration) time.Time {
+	w := wallClock(t)
+	return shiftWallClock(t, w.Round(unit).Sub(w))
+}
+
+// TruncateToMonth returns the first day (00:00:00) of the calendar month of t in t.Location().
+func TruncateToMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// IsLeapYear reports whether year is a leap year in the proleptic Gregorian calendar
+// (divisible by 4, except the centuries not divisible by 400: 1900 is not, 2000 is).
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// DaysInMonth returns the number of days of month in year, February has 29 days in a leap year.
+// A month out of the range [1, 12] is normalized as by time.Date.
+func DaysInMonth(year int, month time.Month) int {
+	// the day 0 of the next month is the last day of month
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Age returns the number of whole years from birth to at, the dates are compared in the
+// location of at and the time of day is ignored. The birthday of February 29 is reached
+// on March 1 in the non-leap years. The result is negative if at is before birth.
+func Age(birth, at time.Time) int {
+	birth = birth.In(at.Location())
+	age := at.Year() - birth.Year()
+	if at.Month() < birth.Month() || (at.Month() == birth.Month() && at.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+// EachDay calls fn for each calendar day from start (inclusive) to end (exclusive),
+// keeping the wall clock of start in its location, so a step over a DST change is not 24h
+// and a range from a local midnight stays on the local midnights. It stops and returns
+// the first error returned by fn.
+func EachDay(start, end time.Time, fn func(day time.Time) error) error {
+	y, m, d := start.Date()
+	h, mi, sec := start.Clock()
+	for i := 0; ; i++ {
+		day := time.Date(y, m, d+i, h, mi, sec, start.Nanosecond(), start.Location())
+		if !day.Before(end) {
+			return nil
+		}
+		if err := fn(day); err != nil {
+			return err
+		}
+	}
+}
+
+// EachMonth is like EachDay but steps one calendar month at a time, the day of start
+// is clamped to the last day of the shorter months (January 31 is followed by February 28 or 29,
+// then March 31).
+func EachMonth(start, end time.Time, fn func(month time.Time) error) error {
+	y, m, d := start.Date()
+	h, mi, sec := start.Clock()
+	for i := 0; ; i++ {
+		first := time.Date(y, m+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+		day := d
+		if n := DaysInMonth(first.Year(), first.Month()); day > n {
+			day = n
+		}
+		month := time.Date(first.Year(), first.Month(), day, h, mi, sec, start.Nanosecond(), start.Location())
+		if !month.Before(end) {
+			return nil
+		}
+		if err := fn(month); err != nil {
+			return err
+		}
+	}
+}
+
+// wallClock returns the wall clock of t as an UTC time.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// shiftWallClock moves t by d keeping its zone offset, so a time in the repeated hour
+// of a DST fall-back stays on its side of the change. If the result is in another
+// zone period, it is rebuilt from the moved wall clock in t.Location() instead.
+func shiftWallClock(t time.Time, d time.Duration) time.Time {
+	res := t.Add(d).Round(0)
+	_, offset := t.Zone()
+	if _, resOffset := res.Zone(); resOffset != offset {
+		w := wallClock(t).Add(d)
+		return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), t.Location())
+	}
+	return res
+}
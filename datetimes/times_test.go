@@ -18,7 +18,9 @@
 package datetimes
 
 import (
+	"errors"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -46,3 +48,271 @@ func TestUnixtimeToTime(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatUnixtime(t *testing.T) {
+	type args struct {
+		v      float64
+		layout string
+		loc    *time.Location
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{v: 1736640000},
+			want: "2025-01-12T00:00:00Z",
+		},
+		{
+			name: "layout",
+			args: args{v: 1736640000.25, layout: "2006-01-02 15:04:05.000"},
+			want: "2025-01-12 00:00:00.250",
+		},
+		{
+			name: "location",
+			args: args{v: 1736640000, layout: time.RFC3339, loc: time.FixedZone("JST", 9*60*60)},
+			want: "2025-01-12T09:00:00+09:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatUnixtime(tt.args.v, tt.args.layout, tt.args.loc); got != tt.want {
+				t.Errorf("FormatUnixtime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateTo(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	type args struct {
+		t    time.Time
+		unit time.Duration
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantTrunc time.Time
+		wantRound time.Time
+	}{
+		{
+			name:      "hour",
+			args:      args{t: time.Date(2025, 1, 12, 10, 45, 30, 0, jst), unit: time.Hour},
+			wantTrunc: time.Date(2025, 1, 12, 10, 0, 0, 0, jst),
+			wantRound: time.Date(2025, 1, 12, 11, 0, 0, 0, jst),
+		},
+		{
+			name:      "local day",
+			args:      args{t: time.Date(2025, 1, 12, 5, 0, 0, 0, jst), unit: 24 * time.Hour},
+			wantTrunc: time.Date(2025, 1, 12, 0, 0, 0, 0, jst),
+			wantRound: time.Date(2025, 1, 12, 0, 0, 0, 0, jst),
+		},
+		{
+			name:      "month boundary",
+			args:      args{t: time.Date(2024, 1, 31, 23, 40, 0, 0, jst), unit: time.Hour},
+			wantTrunc: time.Date(2024, 1, 31, 23, 0, 0, 0, jst),
+			wantRound: time.Date(2024, 2, 1, 0, 0, 0, 0, jst),
+		},
+		{
+			name:      "dst start",
+			args:      args{t: time.Date(2024, 3, 10, 15, 30, 0, 0, ny), unit: 24 * time.Hour},
+			wantTrunc: time.Date(2024, 3, 10, 0, 0, 0, 0, ny),
+			wantRound: time.Date(2024, 3, 11, 0, 0, 0, 0, ny),
+		},
+		{
+			// 01:30 EST, the second 01:30 of the day
+			name:      "dst end hour",
+			args:      args{t: time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC).In(ny), unit: time.Hour},
+			wantTrunc: time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC),
+			wantRound: time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "dst end minute",
+			args:      args{t: time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC).In(ny), unit: time.Minute},
+			wantTrunc: time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC),
+			wantRound: time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			// 01:20 EDT, the first 01:20 of the day
+			name:      "dst end first hour",
+			args:      args{t: time.Date(2024, 11, 3, 5, 20, 0, 0, time.UTC).In(ny), unit: time.Hour},
+			wantTrunc: time.Date(2024, 11, 3, 5, 0, 0, 0, time.UTC),
+			wantRound: time.Date(2024, 11, 3, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "dst end day",
+			args:      args{t: time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC).In(ny), unit: 24 * time.Hour},
+			wantTrunc: time.Date(2024, 11, 3, 0, 0, 0, 0, ny),
+			wantRound: time.Date(2024, 11, 3, 0, 0, 0, 0, ny),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateTo(tt.args.t, tt.args.unit); !got.Equal(tt.wantTrunc) || got.Location() != tt.args.t.Location() {
+				t.Errorf("TruncateTo() = %v, want %v", got, tt.wantTrunc)
+			}
+			if got := RoundTo(tt.args.t, tt.args.unit); !got.Equal(tt.wantRound) || got.Location() != tt.args.t.Location() {
+				t.Errorf("RoundTo() = %v, want %v", got, tt.wantRound)
+			}
+		})
+	}
+}
+
+func TestTruncateToMonth(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "end of month", t: time.Date(2024, 3, 31, 23, 59, 59, 999, jst), want: time.Date(2024, 3, 1, 0, 0, 0, 0, jst)},
+		{name: "first day", t: time.Date(2024, 2, 1, 0, 0, 0, 0, jst), want: time.Date(2024, 2, 1, 0, 0, 0, 0, jst)},
+		// 2024-03-01 00:30 JST is still February in UTC
+		{name: "local month", t: time.Date(2024, 3, 1, 0, 30, 0, 0, jst), want: time.Date(2024, 3, 1, 0, 0, 0, 0, jst)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateToMonth(tt.t); !got.Equal(tt.want) {
+				t.Errorf("TruncateToMonth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLeapYear(t *testing.T) {
+	tests := []struct {
+		year int
+		want bool
+	}{
+		{year: 2024, want: true},
+		{year: 2023, want: false},
+		{year: 1900, want: false},
+		{year: 2000, want: true},
+		{year: 2100, want: false},
+		{year: 0, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.year), func(t *testing.T) {
+			if got := IsLeapYear(tt.year); got != tt.want {
+				t.Errorf("IsLeapYear() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		name  string
+		year  int
+		month time.Month
+		want  int
+	}{
+		{name: "leap february", year: 2024, month: time.February, want: 29},
+		{name: "february", year: 2023, month: time.February, want: 28},
+		{name: "century february", year: 1900, month: time.February, want: 28},
+		{name: "400 years february", year: 2000, month: time.February, want: 29},
+		{name: "january", year: 2023, month: time.January, want: 31},
+		{name: "april", year: 2023, month: time.April, want: 30},
+		{name: "december", year: 2023, month: time.December, want: 31},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysInMonth(tt.year, tt.month); got != tt.want {
+				t.Errorf("DaysInMonth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 12, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name  string
+		birth time.Time
+		at    time.Time
+		want  int
+	}{
+		{name: "day before birthday", birth: date(1990, 6, 15), at: date(2024, 6, 14), want: 33},
+		{name: "birthday", birth: date(1990, 6, 15), at: date(2024, 6, 15), want: 34},
+		{name: "after birthday", birth: date(1990, 6, 15), at: date(2024, 12, 1), want: 34},
+		{name: "earlier month", birth: date(1990, 6, 15), at: date(2024, 5, 20), want: 33},
+		{name: "birth day", birth: date(2024, 6, 15), at: date(2024, 6, 15), want: 0},
+		{name: "feb 29 in non-leap year", birth: date(2000, 2, 29), at: date(2023, 2, 28), want: 22},
+		{name: "feb 29 on march 1", birth: date(2000, 2, 29), at: date(2023, 3, 1), want: 23},
+		{name: "feb 29 in leap year", birth: date(2000, 2, 29), at: date(2024, 2, 29), want: 24},
+		{name: "before birth", birth: date(2000, 6, 15), at: date(1999, 6, 15), want: -1},
+		// 2024-06-14 20:00 UTC is already the birthday in JST
+		{name: "location of at", birth: time.Date(1990, 6, 15, 0, 0, 0, 0, jst),
+			at: time.Date(2024, 6, 14, 20, 0, 0, 0, time.UTC).In(jst), want: 34},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Age(tt.birth, tt.at); got != tt.want {
+				t.Errorf("Age() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEachDay(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	// the DST starts on 2024-03-10 in New York, that day has 23 hours
+	start := time.Date(2024, 3, 9, 0, 0, 0, 0, ny)
+	end := time.Date(2024, 3, 12, 0, 0, 0, 0, ny)
+	var got []string
+	err = EachDay(start, end, func(day time.Time) error {
+		got = append(got, day.Format("2006-01-02 15:04 MST"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachDay() error = %v", err)
+	}
+	want := []string{"2024-03-09 00:00 EST", "2024-03-10 00:00 EST", "2024-03-11 00:00 EDT"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EachDay() = %v, want %v", got, want)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = EachDay(start, end, func(time.Time) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("EachDay() error = %v, calls = %d, want %v, 1", err, calls, stop)
+	}
+	if err = EachDay(end, start, func(time.Time) error { return stop }); err != nil {
+		t.Errorf("EachDay() with end before start error = %v, want nil", err)
+	}
+}
+
+func TestEachMonth(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, ny)
+	end := time.Date(2024, 5, 1, 0, 0, 0, 0, ny)
+	var got []string
+	err = EachMonth(start, end, func(month time.Time) error {
+		got = append(got, month.Format("2006-01-02 15:04 MST"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachMonth() error = %v", err)
+	}
+	want := []string{"2024-01-31 00:00 EST", "2024-02-29 00:00 EST", "2024-03-31 00:00 EDT", "2024-04-30 00:00 EDT"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EachMonth() = %v, want %v", got, want)
+	}
+}
@@ -17,6 +17,11 @@
  */
 package fields
 
+// JSON tokens used by the Str* field types.
+//
+// JsonChar is the quoting wrapper written around every Str* value by MarshalJSON,
+// so StrInt(255) is encoded as "255" (with the quotes), the same as StrInt64,
+// StrFloat and StrFloat64. UnmarshalJSON accepts both quoted and bare numbers.
 const (
 	JsonChar            = "\""
 	JsonSlicePrefixChar = "["
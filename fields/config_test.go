@@ -0,0 +1,51 @@
+// Package fields
+package fields
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJsonChar(t *testing.T) {
+	type entry struct {
+		Int     StrInt     `json:"int"`
+		Int64   StrInt64   `json:"int64"`
+		Float   StrFloat   `json:"float"`
+		Float64 StrFloat64 `json:"float64"`
+	}
+	tests := []struct {
+		name  string
+		entry entry
+		want  string
+	}{
+		{
+			name:  "zero",
+			entry: entry{},
+			want:  `{"int":"0","int64":"0","float":"0","float64":"0"}`,
+		},
+		{
+			name:  "values",
+			entry: entry{Int: -255, Int64: 9007199254740993, Float: 3.1415926535, Float64: 3.1415926535},
+			want:  `{"int":"-255","int64":"9007199254740993","float":"3.1415927","float64":"3.1415926535"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.entry)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() got = %s, want %s", got, tt.want)
+			}
+
+			var back entry
+			if err = json.Unmarshal(got, &back); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if back != tt.entry {
+				t.Errorf("Unmarshal() got = %+v, want %+v", back, tt.entry)
+			}
+		})
+	}
+}
@@ -18,52 +18,68 @@
 package fields
 
 import (
-	"strconv"
-	"strings"
-
 	"github.com/pashifika/util/conv"
+	"github.com/pashifika/util/mem"
 )
 
+// StrFloat is a float32 encoded as a quoted JSON string, see JsonChar.
 type StrFloat float32
 
 func (s StrFloat) Value() float32 { return float32(s) }
 
 // MarshalJSON returns the encoded JSON string.
 func (s StrFloat) MarshalJSON() ([]byte, error) {
-	str := strconv.FormatFloat(float64(s), 'g', -1, 32)
-	str = JsonChar + str + JsonChar
-	return conv.StringToBytes(str), nil
+	return marshalNumJSON(float32(s), 32), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s StrFloat) AppendJSON(dst *mem.FakeIO) {
+	appendFloatJSON(dst, float64(s), 32)
 }
 
 // UnmarshalJSON sets the value that decoded JSON.
-func (s *StrFloat) UnmarshalJSON(data []byte) (err error) {
-	str := conv.BytesToString(data)
-	str = strings.TrimPrefix(strings.TrimSuffix(str, JsonChar), JsonChar)
-	v, err := strconv.ParseFloat(str, 32)
-	if err == nil {
-		*s = StrFloat(v)
-	}
-	return err
+func (s *StrFloat) UnmarshalJSON(data []byte) error {
+	return unmarshalNumJSON((*float32)(s), data, 32)
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s StrFloat) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(float32(s), 32)), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *StrFloat) UnmarshalText(text []byte) error {
+	return parseNum((*float32)(s), conv.BytesToString(text), 32)
 }
 
+// StrFloat64 is a float64 encoded as a quoted JSON string, see JsonChar.
 type StrFloat64 float64
 
 func (s StrFloat64) Value() float64 { return float64(s) }
 
 // MarshalJSON returns the encoded JSON string.
 func (s StrFloat64) MarshalJSON() ([]byte, error) {
-	str := strconv.FormatFloat(float64(s), 'g', -1, 64)
-	str = JsonChar + str + JsonChar
-	return conv.StringToBytes(str), nil
+	return marshalNumJSON(float64(s), 64), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s StrFloat64) AppendJSON(dst *mem.FakeIO) {
+	appendFloatJSON(dst, float64(s), 64)
 }
 
 // UnmarshalJSON sets the value that decoded JSON.
-func (s *StrFloat64) UnmarshalJSON(data []byte) (err error) {
-	str := conv.BytesToString(data)
-	str = strings.TrimPrefix(strings.TrimSuffix(str, JsonChar), JsonChar)
-	v, err := strconv.ParseFloat(str, 64)
-	if err == nil {
-		*s = StrFloat64(v)
-	}
-	return err
+func (s *StrFloat64) UnmarshalJSON(data []byte) error {
+	return unmarshalNumJSON((*float64)(s), data, 64)
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s StrFloat64) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(float64(s), 64)), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *StrFloat64) UnmarshalText(text []byte) error {
+	return parseNum((*float64)(s), conv.BytesToString(text), 64)
 }
@@ -0,0 +1,149 @@
+// Package fields
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package fields
+
+import (
+	"github.com/pashifika/util/conv"
+	"github.com/pashifika/util/mem"
+)
+
+// StrictStrFloat is a StrFloat which decodes with conv.ParseFloatStrict,
+// so "Inf", "NaN" and the out of range values are rejected, e.g. for amounts.
+type StrictStrFloat float32
+
+func (s StrictStrFloat) Value() float32 { return float32(s) }
+
+// MarshalJSON returns the encoded JSON string.
+func (s StrictStrFloat) MarshalJSON() ([]byte, error) {
+	return marshalNumJSON(float32(s), 32), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s StrictStrFloat) AppendJSON(dst *mem.FakeIO) {
+	appendFloatJSON(dst, float64(s), 32)
+}
+
+// UnmarshalJSON sets the value that decoded JSON.
+func (s *StrictStrFloat) UnmarshalJSON(data []byte) error {
+	return parseFloatFunc((*float32)(s), jsonNumString(data), 32, conv.ParseFloatStrict)
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s StrictStrFloat) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(float32(s), 32)), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *StrictStrFloat) UnmarshalText(text []byte) error {
+	return parseFloatFunc((*float32)(s), conv.BytesToString(text), 32, conv.ParseFloatStrict)
+}
+
+// StrictStrFloat64 is the float64 version of StrictStrFloat.
+type StrictStrFloat64 float64
+
+func (s StrictStrFloat64) Value() float64 { return float64(s) }
+
+// MarshalJSON returns the encoded JSON string.
+func (s StrictStrFloat64) MarshalJSON() ([]byte, error) {
+	return marshalNumJSON(float64(s), 64), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s StrictStrFloat64) AppendJSON(dst *mem.FakeIO) {
+	appendFloatJSON(dst, float64(s), 64)
+}
+
+// UnmarshalJSON sets the value that decoded JSON.
+func (s *StrictStrFloat64) UnmarshalJSON(data []byte) error {
+	return parseFloatFunc((*float64)(s), jsonNumString(data), 64, conv.ParseFloatStrict)
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s StrictStrFloat64) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(float64(s), 64)), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *StrictStrFloat64) UnmarshalText(text []byte) error {
+	return parseFloatFunc((*float64)(s), conv.BytesToString(text), 64, conv.ParseFloatStrict)
+}
+
+// PlainStrFloat is a StrictStrFloat which also rejects the exponent notation like "1e3",
+// it decodes with conv.ParseFloatPlain.
+type PlainStrFloat float32
+
+func (s PlainStrFloat) Value() float32 { return float32(s) }
+
+// MarshalJSON returns the encoded JSON string.
+func (s PlainStrFloat) MarshalJSON() ([]byte, error) {
+	return marshalNumJSON(float32(s), 32), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s PlainStrFloat) AppendJSON(dst *mem.FakeIO) {
+	appendFloatJSON(dst, float64(s), 32)
+}
+
+// UnmarshalJSON sets the value that decoded JSON.
+func (s *PlainStrFloat) UnmarshalJSON(data []byte) error {
+	return parseFloatFunc((*float32)(s), jsonNumString(data), 32, conv.ParseFloatPlain)
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s PlainStrFloat) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(float32(s), 32)), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *PlainStrFloat) UnmarshalText(text []byte) error {
+	return parseFloatFunc((*float32)(s), conv.BytesToString(text), 32, conv.ParseFloatPlain)
+}
+
+// PlainStrFloat64 is the float64 version of PlainStrFloat.
+type PlainStrFloat64 float64
+
+func (s PlainStrFloat64) Value() float64 { return float64(s) }
+
+// MarshalJSON returns the encoded JSON string.
+func (s PlainStrFloat64) MarshalJSON() ([]byte, error) {
+	return marshalNumJSON(float64(s), 64), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s PlainStrFloat64) AppendJSON(dst *mem.FakeIO) {
+	appendFloatJSON(dst, float64(s), 64)
+}
+
+// UnmarshalJSON sets the value that decoded JSON.
+func (s *PlainStrFloat64) UnmarshalJSON(data []byte) error {
+	return parseFloatFunc((*float64)(s), jsonNumString(data), 64, conv.ParseFloatPlain)
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s PlainStrFloat64) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(float64(s), 64)), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *PlainStrFloat64) UnmarshalText(text []byte) error {
+	return parseFloatFunc((*float64)(s), conv.BytesToString(text), 64, conv.ParseFloatPlain)
+}
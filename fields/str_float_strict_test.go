@@ -0,0 +1,89 @@
+// Package fields
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package fields
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStrictStrFloat(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantErr   bool
+		wantPlain bool
+	}{
+		{name: "decimal", data: "12.5", wantErr: false, wantPlain: false},
+		{name: "exponent", data: "1.25e1", wantErr: false, wantPlain: true},
+		{name: "inf", data: "Inf", wantErr: true, wantPlain: true},
+		{name: "nan", data: "NaN", wantErr: true, wantPlain: true},
+		{name: "overflow", data: "1e309", wantErr: true, wantPlain: true},
+		{name: "overflow float32", data: "1e39", wantErr: true, wantPlain: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// StrFloat64 keeps accepting any value of strconv.ParseFloat
+			var loose StrFloat64
+			if err := loose.UnmarshalText([]byte(tt.data)); (err != nil) != (tt.name == "overflow") {
+				t.Errorf("StrFloat64.UnmarshalText() error = %v", err)
+			}
+
+			decoders := []struct {
+				name    string
+				v       unmarshaler
+				wantErr bool
+			}{
+				{name: "StrictStrFloat", v: new(StrictStrFloat), wantErr: tt.wantErr},
+				{name: "StrictStrFloat64", v: new(StrictStrFloat64), wantErr: tt.wantErr && tt.name != "overflow float32"},
+				{name: "PlainStrFloat", v: new(PlainStrFloat), wantErr: tt.wantPlain},
+				{name: "PlainStrFloat64", v: new(PlainStrFloat64), wantErr: tt.wantPlain},
+			}
+			for _, d := range decoders {
+				if err := d.v.UnmarshalJSON([]byte(JsonChar + tt.data + JsonChar)); (err != nil) != d.wantErr {
+					t.Errorf("%s.UnmarshalJSON() error = %v, wantErr %v", d.name, err, d.wantErr)
+				}
+				if err := d.v.UnmarshalText([]byte(tt.data)); (err != nil) != d.wantErr {
+					t.Errorf("%s.UnmarshalText() error = %v, wantErr %v", d.name, err, d.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestStrictStrFloat_MatchStrFloat(t *testing.T) {
+	type entry struct {
+		Float   StrictStrFloat   `json:"float"`
+		Float64 StrictStrFloat64 `json:"float64"`
+		Plain   PlainStrFloat    `json:"plain"`
+		Plain64 PlainStrFloat64  `json:"plain64"`
+	}
+	in := entry{Float: 3.1415926535, Float64: 3.1415926535, Plain: -0.5, Plain64: 1234.56}
+	got, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"float":"3.1415927","float64":"3.1415926535","plain":"-0.5","plain64":"1234.56"}`
+	if string(got) != want {
+		t.Errorf("Marshal() got = %s, want %s", got, want)
+	}
+	var back entry
+	if err = json.Unmarshal(got, &back); err != nil || back != in {
+		t.Errorf("Unmarshal() got = %+v, %v, want %+v", back, err, in)
+	}
+}
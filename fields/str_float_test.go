@@ -107,3 +107,67 @@ func TestStrFloat64_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestStrFloat_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    StrFloat
+		want string
+	}{
+		{name: "test 01", s: 3.1415926535, want: "3.1415927"},
+		{name: "test 02", s: -3.1415, want: "-3.1415"},
+		{name: "test 03", s: 0, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalText() got = %s, want %s", got, tt.want)
+			}
+			var back StrFloat
+			if err = back.UnmarshalText(got); err != nil {
+				t.Fatalf("UnmarshalText() error = %v", err)
+			}
+			if back != tt.s {
+				t.Errorf("UnmarshalText() got = %v, want %v", back, tt.s)
+			}
+		})
+	}
+}
+
+func TestStrFloat64_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    StrFloat64
+		want string
+	}{
+		{name: "test 01", s: 3.1415926535, want: "3.1415926535"},
+		{name: "test 02", s: -3.1415, want: "-3.1415"},
+		{name: "test 03", s: 0, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalText() got = %s, want %s", got, tt.want)
+			}
+			var back StrFloat64
+			if err = back.UnmarshalText(got); err != nil {
+				t.Fatalf("UnmarshalText() error = %v", err)
+			}
+			if back != tt.s {
+				t.Errorf("UnmarshalText() got = %v, want %v", back, tt.s)
+			}
+		})
+	}
+	var s StrFloat64
+	if err := s.UnmarshalText([]byte("3.1s")); err == nil {
+		t.Errorf("UnmarshalText() error = nil, want error")
+	}
+}
@@ -18,52 +18,68 @@
 package fields
 
 import (
-	"strconv"
-	"strings"
-
 	"github.com/pashifika/util/conv"
+	"github.com/pashifika/util/mem"
 )
 
+// StrInt is an int encoded as a quoted JSON string, see JsonChar.
 type StrInt int
 
 func (s StrInt) Value() int { return int(s) }
 
 // MarshalJSON returns the encoded JSON string.
 func (s StrInt) MarshalJSON() ([]byte, error) {
-	str := strconv.FormatInt(int64(s), 10)
-	str = JsonChar + str + JsonChar
-	return conv.StringToBytes(str), nil
+	return marshalNumJSON(int(s), 64), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s StrInt) AppendJSON(dst *mem.FakeIO) {
+	appendIntJSON(dst, int64(s))
 }
 
 // UnmarshalJSON sets the value that decoded JSON.
-func (s *StrInt) UnmarshalJSON(data []byte) (err error) {
-	str := conv.BytesToString(data)
-	str = strings.TrimPrefix(strings.TrimSuffix(str, JsonChar), JsonChar)
-	v, err := strconv.ParseInt(str, 10, 32)
-	if err == nil {
-		*s = StrInt(v)
-	}
-	return err
+func (s *StrInt) UnmarshalJSON(data []byte) error {
+	return unmarshalNumJSON((*int)(s), data, 32)
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s StrInt) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(int(s), 64)), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *StrInt) UnmarshalText(text []byte) error {
+	return parseNum((*int)(s), conv.BytesToString(text), 32)
 }
 
+// StrInt64 is an int64 encoded as a quoted JSON string, see JsonChar.
 type StrInt64 int64
 
 func (s StrInt64) Value() int64 { return int64(s) }
 
 // MarshalJSON returns the encoded JSON string.
 func (s StrInt64) MarshalJSON() ([]byte, error) {
-	str := strconv.FormatInt(int64(s), 10)
-	str = JsonChar + str + JsonChar
-	return conv.StringToBytes(str), nil
+	return marshalNumJSON(int64(s), 64), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s StrInt64) AppendJSON(dst *mem.FakeIO) {
+	appendIntJSON(dst, int64(s))
 }
 
 // UnmarshalJSON sets the value that decoded JSON.
-func (s *StrInt64) UnmarshalJSON(data []byte) (err error) {
-	str := conv.BytesToString(data)
-	str = strings.TrimPrefix(strings.TrimSuffix(str, JsonChar), JsonChar)
-	v, err := strconv.ParseInt(str, 10, 64)
-	if err == nil {
-		*s = StrInt64(v)
-	}
-	return err
+func (s *StrInt64) UnmarshalJSON(data []byte) error {
+	return unmarshalNumJSON((*int64)(s), data, 64)
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s StrInt64) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(int64(s), 64)), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *StrInt64) UnmarshalText(text []byte) error {
+	return parseNum((*int64)(s), conv.BytesToString(text), 64)
 }
@@ -103,3 +103,67 @@ func TestStrInt64_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestStrInt_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    StrInt
+		want string
+	}{
+		{name: "test 01", s: 255, want: "255"},
+		{name: "test 02", s: -255, want: "-255"},
+		{name: "test 03", s: 0, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalText() got = %s, want %s", got, tt.want)
+			}
+			var back StrInt
+			if err = back.UnmarshalText(got); err != nil {
+				t.Fatalf("UnmarshalText() error = %v", err)
+			}
+			if back != tt.s {
+				t.Errorf("UnmarshalText() got = %v, want %v", back, tt.s)
+			}
+		})
+	}
+	var s StrInt
+	if err := s.UnmarshalText([]byte("\"255\"")); err == nil {
+		t.Errorf("UnmarshalText() error = nil, want quoted text rejected")
+	}
+}
+
+func TestStrInt64_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    StrInt64
+		want string
+	}{
+		{name: "test 01", s: 9007199254740993, want: "9007199254740993"},
+		{name: "test 02", s: -255, want: "-255"},
+		{name: "test 03", s: 0, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalText() got = %s, want %s", got, tt.want)
+			}
+			var back StrInt64
+			if err = back.UnmarshalText(got); err != nil {
+				t.Fatalf("UnmarshalText() error = %v", err)
+			}
+			if back != tt.s {
+				t.Errorf("UnmarshalText() got = %v, want %v", back, tt.s)
+			}
+		})
+	}
+}
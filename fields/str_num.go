@@ -0,0 +1,208 @@
+// Package fields
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package fields
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+
+	"github.com/pashifika/util/conv"
+	"github.com/pashifika/util/mem"
+)
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// StrNum is a generic number encoded as a quoted JSON string, see JsonChar.
+// The bit size used to format and parse the value is the size of T,
+// except for int and uint which are parsed in 32 bits like StrInt.
+type StrNum[T Number] struct {
+	v T
+}
+
+// NewStrNum returns a StrNum holding v.
+func NewStrNum[T Number](v T) StrNum[T] { return StrNum[T]{v: v} }
+
+func (s StrNum[T]) Value() T { return s.v }
+
+// MarshalJSON returns the encoded JSON string.
+func (s StrNum[T]) MarshalJSON() ([]byte, error) {
+	return marshalNumJSON(s.v, numBitSize(s.v)), nil
+}
+
+// AppendJSON writes the encoded JSON string at the end of dst, like MarshalJSON
+// but without allocating when dst has enough capacity.
+func (s StrNum[T]) AppendJSON(dst *mem.FakeIO) {
+	appendNumJSON(dst, s.v, numBitSize(s.v))
+}
+
+// UnmarshalJSON sets the value that decoded JSON.
+func (s *StrNum[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalNumJSON(&s.v, data, numBitSize(s.v))
+}
+
+// MarshalText returns the bare numeric text, implements the encoding.TextMarshaler interface.
+func (s StrNum[T]) MarshalText() ([]byte, error) {
+	return conv.StringToBytes(formatNum(s.v, numBitSize(s.v))), nil
+}
+
+// UnmarshalText sets the value that decoded numeric text, implements the encoding.TextUnmarshaler interface.
+func (s *StrNum[T]) UnmarshalText(text []byte) error {
+	return parseNum(&s.v, conv.BytesToString(text), numBitSize(s.v))
+}
+
+// Kinds of the underlying type of a Number, see numKind.
+const (
+	kindInt = iota
+	kindUint
+	kindFloat
+)
+
+// numKind returns the kind of the underlying type of v, the predeclared types
+// are switched on directly and only the named types fall back to reflect.
+func numKind[T Number](v T) int {
+	switch any(v).(type) {
+	case int, int8, int16, int32, int64:
+		return kindInt
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		return kindUint
+	case float32, float64:
+		return kindFloat
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return kindUint
+	default:
+		return kindInt
+	}
+}
+
+// numBitSize returns the bit size of the underlying type of v, but 32 for int and uint
+// as StrInt always parsed them, so a value is decoded the same on every platform.
+func numBitSize[T Number](v T) int {
+	switch any(v).(type) {
+	case int8, uint8:
+		return 8
+	case int16, uint16:
+		return 16
+	case int, uint, int32, uint32, float32:
+		return 32
+	case int64, uint64, float64:
+		return 64
+	}
+	typ := reflect.TypeOf(v)
+	if k := typ.Kind(); k == reflect.Int || k == reflect.Uint {
+		return 32
+	}
+	return typ.Bits()
+}
+
+// formatNum formats v by the kind of its underlying type,
+// floats use the smallest precision that round-trips at bitSize.
+func formatNum[T Number](v T, bitSize int) string {
+	switch numKind(v) {
+	case kindFloat:
+		return strconv.FormatFloat(float64(v), 'g', -1, bitSize)
+	case kindUint:
+		return strconv.FormatUint(uint64(v), 10)
+	default:
+		return strconv.FormatInt(int64(v), 10)
+	}
+}
+
+// parseNum parses str by the kind of *v's underlying type and stores it in *v,
+// *v is unchanged if str is invalid.
+func parseNum[T Number](v *T, str string, bitSize int) error {
+	switch numKind(*v) {
+	case kindFloat:
+		return parseFloatFunc(v, str, bitSize, strconv.ParseFloat)
+	case kindUint:
+		u, err := strconv.ParseUint(str, 10, bitSize)
+		if err == nil {
+			*v = T(u)
+		}
+		return err
+	default:
+		i, err := strconv.ParseInt(str, 10, bitSize)
+		if err == nil {
+			*v = T(i)
+		}
+		return err
+	}
+}
+
+// marshalNumJSON returns v formatted and wrapped by JsonChar.
+func marshalNumJSON[T Number](v T, bitSize int) []byte {
+	return conv.StringToBytes(JsonChar + formatNum(v, bitSize) + JsonChar)
+}
+
+// appendNumJSON writes v formatted like formatNum and wrapped by JsonChar at the end of dst.
+func appendNumJSON[T Number](dst *mem.FakeIO, v T, bitSize int) {
+	switch numKind(v) {
+	case kindFloat:
+		appendFloatJSON(dst, float64(v), bitSize)
+	case kindUint:
+		b := append(dst.AvailableBuffer(), JsonChar...)
+		b = conv.AppendUint(b, uint64(v))
+		_, _ = dst.Write(append(b, JsonChar...))
+	default:
+		appendIntJSON(dst, int64(v))
+	}
+}
+
+// appendIntJSON writes n wrapped by JsonChar at the end of dst.
+func appendIntJSON(dst *mem.FakeIO, n int64) {
+	b := append(dst.AvailableBuffer(), JsonChar...)
+	b = conv.AppendInt(b, n)
+	_, _ = dst.Write(append(b, JsonChar...))
+}
+
+// appendFloatJSON writes f wrapped by JsonChar at the end of dst,
+// with the smallest precision that round-trips at bitSize.
+func appendFloatJSON(dst *mem.FakeIO, f float64, bitSize int) {
+	b := append(dst.AvailableBuffer(), JsonChar...)
+	b = strconv.AppendFloat(b, f, 'g', -1, bitSize)
+	_, _ = dst.Write(append(b, JsonChar...))
+}
+
+// parseFloatFunc parses str with parse and stores it in *v, *v is unchanged if str is invalid.
+func parseFloatFunc[T Number](v *T, str string, bitSize int, parse func(s string, bitSize int) (float64, error)) error {
+	f, err := parse(str, bitSize)
+	if err == nil {
+		*v = T(f)
+	}
+	return err
+}
+
+// unmarshalNumJSON parses the quoted or bare JSON number in data into *v.
+func unmarshalNumJSON[T Number](v *T, data []byte, bitSize int) error {
+	return parseNum(v, jsonNumString(data), bitSize)
+}
+
+// jsonNumString returns the quoted or bare JSON number in data without the JsonChar quoting.
+func jsonNumString(data []byte) string {
+	str := conv.BytesToString(data)
+	return strings.TrimPrefix(strings.TrimSuffix(str, JsonChar), JsonChar)
+}
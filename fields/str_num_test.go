@@ -0,0 +1,175 @@
+// Package fields
+package fields
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pashifika/util/mem"
+)
+
+type marshaler interface {
+	MarshalJSON() ([]byte, error)
+	MarshalText() ([]byte, error)
+}
+
+func TestStrNum_MatchConcrete(t *testing.T) {
+	tests := []struct {
+		name     string
+		generic  marshaler
+		concrete marshaler
+	}{
+		{name: "int", generic: NewStrNum(-255), concrete: StrInt(-255)},
+		{name: "int64", generic: NewStrNum(int64(9007199254740993)), concrete: StrInt64(9007199254740993)},
+		{name: "float32", generic: NewStrNum(float32(3.1415926535)), concrete: StrFloat(3.1415926535)},
+		{name: "float64", generic: NewStrNum(3.1415926535), concrete: StrFloat64(3.1415926535)},
+		{name: "float64 zero", generic: NewStrNum(0.0), concrete: StrFloat64(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := tt.generic.MarshalJSON()
+			want, _ := tt.concrete.MarshalJSON()
+			if !bytes.Equal(got, want) {
+				t.Errorf("MarshalJSON() got = %s, want %s", got, want)
+			}
+			got, _ = tt.generic.MarshalText()
+			want, _ = tt.concrete.MarshalText()
+			if !bytes.Equal(got, want) {
+				t.Errorf("MarshalText() got = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+type unmarshaler interface {
+	marshaler
+	UnmarshalJSON(data []byte) error
+	UnmarshalText(text []byte) error
+}
+
+type namedInt8 int8
+
+func TestStrNum_UnmarshalMatchConcrete(t *testing.T) {
+	inputs := []string{"255", "-128", "128", "2147483647", "2147483648", "-2147483649",
+		"9007199254740993", "1.5", "3.4e38", "3.5e38", "1e309", "1s", ""}
+	tests := []struct {
+		name     string
+		generic  func() unmarshaler
+		concrete func() unmarshaler
+	}{
+		{name: "int", generic: func() unmarshaler { return new(StrNum[int]) }, concrete: func() unmarshaler { return new(StrInt) }},
+		{name: "int64", generic: func() unmarshaler { return new(StrNum[int64]) }, concrete: func() unmarshaler { return new(StrInt64) }},
+		{name: "float32", generic: func() unmarshaler { return new(StrNum[float32]) }, concrete: func() unmarshaler { return new(StrFloat) }},
+		{name: "float64", generic: func() unmarshaler { return new(StrNum[float64]) }, concrete: func() unmarshaler { return new(StrFloat64) }},
+		{name: "named int8", generic: func() unmarshaler { return new(StrNum[namedInt8]) }, concrete: func() unmarshaler { return new(StrNum[int8]) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, in := range inputs {
+				g, c := tt.generic(), tt.concrete()
+				gErr, cErr := g.UnmarshalJSON([]byte(JsonChar+in+JsonChar)), c.UnmarshalJSON([]byte(JsonChar+in+JsonChar))
+				gotJSON, _ := g.MarshalJSON()
+				wantJSON, _ := c.MarshalJSON()
+				if (gErr != nil) != (cErr != nil) || !bytes.Equal(gotJSON, wantJSON) {
+					t.Errorf("UnmarshalJSON(%q) = %s, %v, want %s, %v", in, gotJSON, gErr, wantJSON, cErr)
+				}
+
+				g, c = tt.generic(), tt.concrete()
+				gErr, cErr = g.UnmarshalText([]byte(in)), c.UnmarshalText([]byte(in))
+				gotText, _ := g.MarshalText()
+				wantText, _ := c.MarshalText()
+				if (gErr != nil) != (cErr != nil) || !bytes.Equal(gotText, wantText) {
+					t.Errorf("UnmarshalText(%q) = %s, %v, want %s, %v", in, gotText, gErr, wantText, cErr)
+				}
+			}
+		})
+	}
+}
+
+func TestStrNum_UnmarshalJSON(t *testing.T) {
+	var u StrNum[uint8]
+	if err := u.UnmarshalJSON([]byte("\"255\"")); err != nil || u.Value() != 255 {
+		t.Errorf("UnmarshalJSON() = %v, %v, want 255", u.Value(), err)
+	}
+	if err := u.UnmarshalJSON([]byte("\"256\"")); err == nil {
+		t.Errorf("UnmarshalJSON() error = nil, want out of range")
+	}
+	if err := u.UnmarshalJSON([]byte("\"-1\"")); err == nil {
+		t.Errorf("UnmarshalJSON() error = nil, want invalid syntax")
+	}
+
+	var f StrNum[float32]
+	if err := f.UnmarshalJSON([]byte("\"3.1415926535\"")); err != nil || f.Value() != float32(StrFloat(3.1415926535)) {
+		t.Errorf("UnmarshalJSON() = %v, %v, want %v", f.Value(), err, float32(3.1415926535))
+	}
+	if err := f.UnmarshalText([]byte("3.1s")); err == nil {
+		t.Errorf("UnmarshalText() error = nil, want error")
+	}
+}
+
+func TestAppendJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface {
+			marshaler
+			AppendJSON(dst *mem.FakeIO)
+		}
+	}{
+		{name: "StrInt", v: StrInt(-255)},
+		{name: "StrInt small", v: StrInt(7)},
+		{name: "StrInt64", v: StrInt64(9007199254740993)},
+		{name: "StrFloat", v: StrFloat(3.1415926535)},
+		{name: "StrFloat64", v: StrFloat64(3.1415926535)},
+		{name: "StrNum uint64", v: NewStrNum(uint64(18446744073709551615))},
+		{name: "StrNum int8", v: NewStrNum(int8(-128))},
+		{name: "StrNum float32", v: NewStrNum(float32(0.1))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := mem.NewFakeIOString("[")
+			tt.v.AppendJSON(dst)
+			want, _ := tt.v.MarshalJSON()
+			if got := dst.String(); got != "["+string(want) {
+				t.Errorf("AppendJSON() got = %s, want [%s", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkStrInt_AppendJSON(b *testing.B) {
+	vals := make([]StrInt, 10000)
+	for i := range vals {
+		vals[i] = StrInt(i * 7919)
+	}
+	b.Run("AppendJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		dst := mem.NewFakeIO(make([]byte, 0, 128*1024))
+		for i := 0; i < b.N; i++ {
+			dst.Reset()
+			_ = dst.WriteByte('[')
+			for j, v := range vals {
+				if j > 0 {
+					_ = dst.WriteByte(',')
+				}
+				v.AppendJSON(dst)
+			}
+			_ = dst.WriteByte(']')
+		}
+	})
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		dst := mem.NewFakeIO(make([]byte, 0, 128*1024))
+		for i := 0; i < b.N; i++ {
+			dst.Reset()
+			_ = dst.WriteByte('[')
+			for j, v := range vals {
+				if j > 0 {
+					_ = dst.WriteByte(',')
+				}
+				data, _ := v.MarshalJSON()
+				_, _ = dst.Write(data)
+			}
+			_ = dst.WriteByte(']')
+		}
+	})
+}
@@ -19,11 +19,21 @@ package files
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/pashifika/util/conv"
+	"github.com/pashifika/util/random"
 )
 
+// rename is os.Rename, it is replaced in tests to simulate a cross-device move.
+var rename = os.Rename
+
 // FileOpen os full path.
 //
 // w  open the file write-only. (support create a new file)
@@ -58,14 +68,80 @@ func FileOpen(path, mode string) (*os.File, error) {
 
 // ByteToFile write bytes to file.
 // (best to the small file)
-func ByteToFile(path string, buf []byte) (err error) {
-	if Exists(path) {
-		err = os.Remove(path)
+//
+// The bytes are written to a temp file next to path which is then renamed to path,
+// so the existing file is left intact if the write fails. The mode of an existing file is kept.
+func ByteToFile(path string, buf []byte) error {
+	return writeFileAtomic(path, 0664, func(f *os.File) error {
+		_, err := f.Write(buf)
+		return err
+	})
+}
+
+// ReadFileString reads the whole file path as a string.
+// (best to the small file)
+func ReadFileString(path string) (string, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	// buf is not referenced elsewhere, so it can back the string
+	return conv.BytesToString(buf), nil
+}
+
+// WriteFileString writes content to the file path, like ByteToFile the existing file
+// is replaced only when the write succeeds.
+func WriteFileString(path, content string) error {
+	return writeFileAtomic(path, 0664, func(f *os.File) error {
+		_, err := f.WriteString(content)
+		return err
+	})
+}
+
+// writeFileAtomic calls write with a new temp file in the directory of path, then renames it to path.
+// The temp file is removed if any step fails. A new file is created with perm (before umask),
+// an existing file is replaced keeping its mode.
+func writeFileAtomic(path string, perm os.FileMode, write func(f *os.File) error) (err error) {
+	if info, serr := os.Stat(path); serr == nil {
+		perm = info.Mode().Perm()
+	}
+	f, err := createTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp", perm)
+	if err != nil {
+		return
+	}
+	defer func() {
 		if err != nil {
-			return
+			_ = CleanTempFile(f)
+		}
+	}()
+
+	if err = write(f); err != nil {
+		return
+	}
+	if err = f.Sync(); err != nil {
+		return
+	}
+	if err = f.Close(); err != nil {
+		return
+	}
+	return rename(f.Name(), path)
+}
+
+// createTemp is like os.CreateTemp with the name prefix, but the file is created with perm
+// (os.CreateTemp always uses 0600), so the umask applies as for a regular file.
+// A failure to read the random source is returned instead of panicking.
+func createTemp(dir, prefix string, perm os.FileMode) (*os.File, error) {
+	for try := 0; ; try++ {
+		suffix, err := random.RandomErr(8, random.Numeric)
+		if err != nil {
+			return nil, err
 		}
+		f, err := os.OpenFile(filepath.Join(dir, prefix+suffix), os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if os.IsExist(err) && try < 10000 {
+			continue
+		}
+		return f, err
 	}
-	return os.WriteFile(path, buf, 0664)
 }
 
 // BufferToFile write buffer to file.
@@ -111,3 +187,108 @@ func BufferToFile(path string, r io.Reader) (err error) {
 
 	return w.Flush()
 }
+
+// BufferToFileEncoded is like BufferToFile, but the data of r is converted
+// from the charset of dec to UTF-8 on the way to the file.
+func BufferToFileEncoded(path string, r io.Reader, dec *conv.Decoder) error {
+	return BufferToFile(path, dec.GetReader(r))
+}
+
+// CopyFile copy the src file to dst, the file mode of src is preserved.
+// If dst exists it will be truncated, unless it is the same file as src
+// (the same path, a hard link or a symlink to it), which returns an error.
+func CopyFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return
+	}
+	//noinspection ALL
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return
+	}
+	if dstInfo, serr := os.Stat(dst); serr == nil && os.SameFile(info, dstInfo) {
+		return fmt.Errorf("files.CopyFile: %s and %s are the same file", src, dst)
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	if _, err = io.Copy(out, in); err != nil {
+		return
+	}
+	// the mode of OpenFile is masked by umask
+	return out.Chmod(info.Mode().Perm())
+}
+
+// Move renames src to dst, when they are on different devices (EXDEV)
+// it falls back to copy src to dst and remove src.
+func Move(src, dst string) error {
+	err := rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if err = CopyFile(src, dst); err != nil {
+		_ = os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
+
+// Touch creates the path file if it does not exist (including the parent dirs),
+// and updates the access and modification times of the file to now.
+func Touch(path string) error {
+	if err := MkdirIfNotExist(path); err != nil {
+		return err
+	}
+	f, err := FileOpen(path, "w")
+	if err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
+
+// SetModTime sets the access and modification times of the path file to t.
+func SetModTime(path string, t time.Time) error {
+	return os.Chtimes(path, t, t)
+}
+
+// TempFileIn creates a new temporary file in the dir directory, opened for reading and writing.
+// The file name is generated by os.CreateTemp with pattern. dir is required, usually
+// filepath.Dir of the target: a temp file that will be renamed to its target must be
+// created next to it (on the same device) for the rename to be atomic, so unlike
+// os.CreateTemp an empty dir returns an error instead of using os.TempDir.
+//
+// The caller is responsible for removing the file when it is no longer needed,
+// see CleanTempFile.
+func TempFileIn(dir, pattern string) (*os.File, error) {
+	if dir == "" {
+		return nil, errors.New("files.TempFileIn: empty dir, use the directory of the target")
+	}
+	return os.CreateTemp(dir, pattern)
+}
+
+// CleanTempFile closes and removes the temp file f, it is safe to call after f
+// has been closed or renamed (os.ErrClosed and os.ErrNotExist are ignored).
+func CleanTempFile(f *os.File) error {
+	err := f.Close()
+	if err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+	err = os.Remove(f.Name())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+// Package files
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package files
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+
+	"github.com/pashifika/util/conv"
+	"github.com/pashifika/util/random"
+)
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name   string
+		rename func(string, string) error
+	}{
+		{name: "rename", rename: os.Rename},
+		{name: "cross device", rename: func(oldpath, newpath string) error {
+			return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(fn func(string, string) error) { rename = fn }(rename)
+			rename = tt.rename
+
+			dir := t.TempDir()
+			src := filepath.Join(dir, "src.txt")
+			dst := filepath.Join(dir, "dst.txt")
+			if err := os.WriteFile(src, []byte("hello"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if err := Move(src, dst); err != nil {
+				t.Fatalf("Move() error = %v", err)
+			}
+			if Exists(src) {
+				t.Errorf("Move() src still exists")
+			}
+			got, err := os.ReadFile(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != "hello" {
+				t.Errorf("Move() dst = %q, want %q", got, "hello")
+			}
+			info, err := os.Stat(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0600 {
+				t.Errorf("Move() dst mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+			}
+		})
+	}
+}
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	if err := os.WriteFile(src, []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link.txt")
+	if err := os.Symlink(src, link); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		dst     string
+		wantErr bool
+	}{
+		{name: "new file", dst: filepath.Join(dir, "dst.txt")},
+		{name: "same path", dst: src, wantErr: true},
+		{name: "symlink to src", dst: link, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyFile(src, tt.dst); (err != nil) != tt.wantErr {
+				t.Fatalf("CopyFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, path := range []string{src, tt.dst} {
+				if got, _ := os.ReadFile(path); string(got) != "data" {
+					t.Errorf("CopyFile() %s content = %q, want %q", path, got, "data")
+				}
+			}
+		})
+	}
+}
+
+func TestTouch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sub", "touch.txt")
+	if err := Touch(path); err != nil {
+		t.Fatalf("Touch() error = %v", err)
+	}
+	if !Exists(path) {
+		t.Fatalf("Touch() file not created")
+	}
+
+	old := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := SetModTime(path, old); err != nil {
+		t.Fatalf("SetModTime() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("SetModTime() mod time = %v, want %v", info.ModTime(), old)
+	}
+
+	if err = os.WriteFile(path, []byte("keep"), 0664); err != nil {
+		t.Fatal(err)
+	}
+	_ = SetModTime(path, old)
+	if err = Touch(path); err != nil {
+		t.Fatalf("Touch() error = %v", err)
+	}
+	if info, err = os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().After(old) {
+		t.Errorf("Touch() mod time = %v, want after %v", info.ModTime(), old)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "keep" {
+		t.Errorf("Touch() content = %q, want %q", got, "keep")
+	}
+}
+
+func TestTempFileIn(t *testing.T) {
+	dir := t.TempDir()
+	f, err := TempFileIn(dir, "download-*.tmp")
+	if err != nil {
+		t.Fatalf("TempFileIn() error = %v", err)
+	}
+	if got := filepath.Dir(f.Name()); got != dir {
+		t.Errorf("TempFileIn() dir = %s, want %s", got, dir)
+	}
+	if ok, _ := filepath.Match("download-*.tmp", filepath.Base(f.Name())); !ok {
+		t.Errorf("TempFileIn() name = %s, not match the pattern", filepath.Base(f.Name()))
+	}
+
+	if err = CleanTempFile(f); err != nil {
+		t.Fatalf("CleanTempFile() error = %v", err)
+	}
+	if Exists(f.Name()) {
+		t.Errorf("CleanTempFile() file still exists")
+	}
+	// cleanup twice is fine
+	if err = CleanTempFile(f); err != nil {
+		t.Errorf("CleanTempFile() error = %v", err)
+	}
+
+	if f, err = TempFileIn("", "download-*.tmp"); err == nil {
+		_ = CleanTempFile(f)
+		t.Errorf("TempFileIn() with an empty dir error = nil")
+	}
+}
+
+func TestBufferToFileEncoded(t *testing.T) {
+	want := bytes.Repeat([]byte("黄昏よりも昏きもの　血の流れより紅きもの\n"), 500)
+	src, err := japanese.ShiftJIS.NewEncoder().Bytes(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := conv.NewDecoder("shift_jis")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "utf8.txt")
+	if err = BufferToFileEncoded(path, bytes.NewReader(src), dec); err != nil {
+		t.Fatalf("BufferToFileEncoded() error = %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("BufferToFileEncoded() wrote %d bytes, want %d bytes of UTF-8", len(got), len(want))
+	}
+}
+
+func TestByteToFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		rename  func(string, string) error
+		want    string
+		wantErr bool
+	}{
+		{name: "replace", rename: os.Rename, want: "new"},
+		{name: "write failure", rename: func(oldpath, newpath string) error {
+			return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.ENOSPC}
+		}, want: "original", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(fn func(string, string) error) { rename = fn }(rename)
+			rename = tt.rename
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "data.txt")
+			if err := os.WriteFile(path, []byte("original"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if err := ByteToFile(path, []byte("new")); (err != nil) != tt.wantErr {
+				t.Fatalf("ByteToFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ByteToFile() content = %q, want %q", got, tt.want)
+			}
+			if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+				t.Errorf("ByteToFile() mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+				t.Errorf("ByteToFile() left %d files in the directory, want 1", len(entries))
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "new.txt")
+	if err := ByteToFile(path, []byte("created")); err != nil {
+		t.Fatalf("ByteToFile() error = %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "created" {
+		t.Errorf("ByteToFile() content = %q, want %q", got, "created")
+	}
+
+	// a failing random source is an error, not a panic
+	defer func(r io.Reader) { random.Reader = r }(random.Reader)
+	random.Reader = iotest.ErrReader(errors.New("no entropy"))
+	if err := ByteToFile(path, []byte("replaced")); err == nil {
+		t.Errorf("ByteToFile() error = nil, want the random source error")
+	}
+	if got, _ := os.ReadFile(path); string(got) != "created" {
+		t.Errorf("ByteToFile() content = %q, want %q", got, "created")
+	}
+}
+
+func TestReadWriteFileString(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "ascii", content: "hello\nworld\n"},
+		{name: "utf8", content: "こんにちは、世界 🌏\r\n"},
+		{name: "empty", content: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "text.txt")
+			if err := WriteFileString(path, "old content"); err != nil {
+				t.Fatalf("WriteFileString() error = %v", err)
+			}
+			if err := WriteFileString(path, tt.content); err != nil {
+				t.Fatalf("WriteFileString() error = %v", err)
+			}
+			got, err := ReadFileString(path)
+			if err != nil {
+				t.Fatalf("ReadFileString() error = %v", err)
+			}
+			if got != tt.content {
+				t.Errorf("ReadFileString() = %q, want %q", got, tt.content)
+			}
+		})
+	}
+
+	if _, err := ReadFileString(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("ReadFileString() error = %v, want not exist", err)
+	}
+}
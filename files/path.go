@@ -18,6 +18,7 @@
 package files
 
 import (
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -47,16 +48,42 @@ func RemoveNameExt(name string) string {
 	return name[:len(name)-len(filepath.Ext(name))]
 }
 
-// Exists returns whether the given file or directory exists or not
+// Exists returns whether the given file or directory exists or not,
+// it returns false when the path cannot be stat (e.g. permission denied).
 func Exists(path string) bool {
+	ok, _ := ExistsErr(path)
+	return ok
+}
+
+// ExistsErr is like Exists but returns the underlying stat error,
+// a path which does not exist is reported as (false, nil).
+func ExistsErr(path string) (bool, error) {
 	_, err := os.Stat(path)
 	if err == nil {
-		return true
+		return true, nil
 	}
 	if os.IsNotExist(err) {
-		return false
+		return false, nil
 	}
-	return true
+	return false, err
+}
+
+// IsDir returns whether path exists and is a directory, symbolic links are followed.
+func IsDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// IsFile returns whether path exists and is a regular file, symbolic links are followed.
+func IsFile(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+// IsSymlink returns whether path is a symbolic link, the link target may not exist.
+func IsSymlink(path string) bool {
+	fi, err := os.Lstat(path)
+	return err == nil && fi.Mode()&os.ModeSymlink != 0
 }
 
 // MkdirIfNotExist used os.MkdirAll to make path's all dir
@@ -97,3 +124,26 @@ func GetFileList(path, filter string, fullPath bool) ([]string, error) {
 	}
 	return res, nil
 }
+
+// WalkFiles walks the file tree rooted at root like filepath.WalkDir and calls visit for each
+// file whose name matches match, a nil match visits all files. Directories are not visited,
+// when skipDir is not nil and returns true for a directory below root, its whole subtree is skipped.
+// Errors returned by visit (including fs.SkipDir) are handled as in filepath.WalkDir.
+func WalkFiles(root string, match *regexp.Regexp, visit func(path string, info fs.DirEntry) error,
+	skipDir func(path string) bool) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && skipDir != nil && skipDir(path) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if match != nil && !match.MatchString(d.Name()) {
+			return nil
+		}
+		return visit(path, d)
+	})
+}
@@ -0,0 +1,186 @@
+// Package files
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func TestWalkFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{
+		"a.go", "b.txt", "sub/c.go", "sub/deep/d.go", ".git/config", ".git/objects/e.go",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	skipGit := func(path string) bool { return filepath.Base(path) == ".git" }
+	tests := []struct {
+		name    string
+		match   *regexp.Regexp
+		skipDir func(string) bool
+		want    []string
+	}{
+		{name: "all", want: []string{".git/config", ".git/objects/e.go", "a.go", "b.txt", "sub/c.go", "sub/deep/d.go"}},
+		{name: "prune .git", skipDir: skipGit, want: []string{"a.go", "b.txt", "sub/c.go", "sub/deep/d.go"}},
+		{name: "match and prune", match: regexp.MustCompile(`\.go$`), skipDir: skipGit,
+			want: []string{"a.go", "sub/c.go", "sub/deep/d.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := WalkFiles(root, tt.match, func(path string, info fs.DirEntry) error {
+				if info.IsDir() {
+					t.Errorf("WalkFiles() visited directory %s", path)
+				}
+				rel, err := filepath.Rel(root, path)
+				got = append(got, filepath.ToSlash(rel))
+				return err
+			}, tt.skipDir)
+			if err != nil {
+				t.Fatalf("WalkFiles() error = %v", err)
+			}
+			sort.Strings(got)
+			if len(got) != len(tt.want) {
+				t.Fatalf("WalkFiles() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("WalkFiles() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestIsDirFileSymlink(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(file, link); err != nil {
+		t.Skip("symlink not supported:", err)
+	}
+	dangling := filepath.Join(dir, "dangling")
+	if err := os.Symlink(filepath.Join(dir, "missing"), dangling); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		exists    bool
+		isDir     bool
+		isFile    bool
+		isSymlink bool
+	}{
+		{name: "file", path: file, exists: true, isFile: true},
+		{name: "directory", path: dir, exists: true, isDir: true},
+		{name: "symlink", path: link, exists: true, isFile: true, isSymlink: true},
+		{name: "dangling symlink", path: dangling, isSymlink: true},
+		{name: "not exist", path: filepath.Join(dir, "missing")},
+		{name: "stat error", path: filepath.Join(file, "child")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Exists(tt.path); got != tt.exists {
+				t.Errorf("Exists() = %v, want %v", got, tt.exists)
+			}
+			if got := IsDir(tt.path); got != tt.isDir {
+				t.Errorf("IsDir() = %v, want %v", got, tt.isDir)
+			}
+			if got := IsFile(tt.path); got != tt.isFile {
+				t.Errorf("IsFile() = %v, want %v", got, tt.isFile)
+			}
+			if got := IsSymlink(tt.path); got != tt.isSymlink {
+				t.Errorf("IsSymlink() = %v, want %v", got, tt.isSymlink)
+			}
+		})
+	}
+}
+
+func TestExistsPermissionDenied(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for root")
+	}
+	dir := t.TempDir()
+	locked := filepath.Join(dir, "locked")
+	if err := os.Mkdir(locked, 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(locked, "file.txt")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(locked, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(locked, 0755)
+
+	if Exists(file) || IsFile(file) || IsSymlink(file) {
+		t.Errorf("Exists(), IsFile(), IsSymlink() = true for a path behind a denied directory")
+	}
+	if ok, err := ExistsErr(file); ok || !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("ExistsErr() = %v, %v, want false, %v", ok, err, fs.ErrPermission)
+	}
+}
+
+func TestExistsErr(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "file", path: file, want: true},
+		{name: "directory", path: dir, want: true},
+		{name: "not exist", path: filepath.Join(dir, "missing")},
+		{name: "not a directory", path: filepath.Join(file, "child"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExistsErr(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExistsErr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ExistsErr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
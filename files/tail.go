@@ -0,0 +1,135 @@
+// Package files
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// tailPollInterval is the interval TailFollow checks the file for new data, it is reduced in tests.
+var tailPollInterval = 250 * time.Millisecond
+
+// tailStarted is called by TailFollow once it has seeked to the end of the file, it is set in tests.
+var tailStarted func()
+
+// TailFollow reads the lines appended to the file path after the call, like "tail -F",
+// and calls fn with each complete line (without the "\n" or "\r\n" end-of-line).
+// The file is polled for new data. When the file is truncated, it is read again from
+// the start, and when it is replaced (log rotation), the new file is opened and read
+// from the start once the old one is exhausted (its unterminated last line is emitted).
+// A truncation is detected by the file size going below the read offset, so a file
+// truncated then written past the old offset between two polls is not detected,
+// the data before the old offset is skipped.
+//
+// It stops when ctx is done, returning ctx.Err(), or returns the first error returned by fn.
+func TailFollow(ctx context.Context, path string, fn func(line string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		//noinspection ALL
+		f.Close()
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if tailStarted != nil {
+		tailStarted()
+	}
+
+	var partial []byte
+	r := bufio.NewReader(f)
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		// emit the complete lines available
+		for {
+			line, err := r.ReadSlice('\n')
+			offset += int64(len(line))
+			if err == nil {
+				line = bytes.TrimSuffix(line[:len(line)-1], []byte{'\r'})
+				if len(partial) != 0 {
+					line = append(partial, line...)
+					partial = partial[:0]
+				}
+				if err = fn(string(line)); err != nil {
+					return err
+				}
+				continue
+			}
+			partial = append(partial, line...)
+			if err != io.EOF && err != bufio.ErrBufferFull {
+				return err
+			}
+			if err == io.EOF {
+				break
+			}
+		}
+
+		// check the truncation or the rotation before waiting for new data
+		if cur, err := os.Stat(path); err == nil {
+			switch {
+			case !os.SameFile(info, cur):
+				nf, err := os.Open(path)
+				if err != nil {
+					break
+				}
+				//noinspection ALL
+				f.Close()
+				f, info, offset = nf, cur, 0
+				// the old file is complete, so its last line does not need a "\n"
+				if len(partial) != 0 {
+					line := string(bytes.TrimSuffix(partial, []byte{'\r'}))
+					partial = partial[:0]
+					if err = fn(line); err != nil {
+						return err
+					}
+				}
+				r.Reset(f)
+				continue
+			case cur.Size() < offset:
+				if offset, err = f.Seek(0, io.SeekStart); err != nil {
+					return err
+				}
+				partial = partial[:0]
+				r.Reset(f)
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
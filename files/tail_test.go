@@ -0,0 +1,128 @@
+// Package files
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package files
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTailFollow(t *testing.T) {
+	defer func(d time.Duration) { tailPollInterval = d }(tailPollInterval)
+	tailPollInterval = 5 * time.Millisecond
+	started := make(chan struct{}, 2)
+	defer func(fn func()) { tailStarted = fn }(tailStarted)
+	tailStarted = func() { started <- struct{}{} }
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(path, []byte("old line\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lines := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- TailFollow(ctx, path, func(line string) error {
+			lines <- line
+			return nil
+		})
+	}()
+	expect := func(want ...string) {
+		t.Helper()
+		for _, w := range want {
+			select {
+			case got := <-lines:
+				if got != w {
+					t.Fatalf("TailFollow() line = %q, want %q", got, w)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatalf("TailFollow() line %q not delivered", w)
+			}
+		}
+	}
+	write := func(w *os.File, s string) {
+		t.Helper()
+		if _, err := w.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// let TailFollow seek to the end before appending
+	<-started
+	write(f, "line 1\nline 2\r\n")
+	expect("line 1", "line 2")
+	write(f, "partial")
+	time.Sleep(20 * time.Millisecond)
+	write(f, " line\n")
+	expect("partial line")
+
+	// truncation
+	if err = f.Truncate(0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	write(f, "after truncate\n")
+	expect("after truncate")
+
+	// rotation
+	write(f, "last of old")
+	if err = os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	nf, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = nf.Close() }()
+	write(nf, "new file\n")
+	expect("last of old", "new file")
+
+	cancel()
+	select {
+	case err = <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("TailFollow() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("TailFollow() did not stop")
+	}
+
+	stop := errors.New("stop")
+	go func() {
+		<-started
+		_, _ = nf.WriteString("x\n")
+	}()
+	if err = TailFollow(context.Background(), path, func(string) error { return stop }); err != stop {
+		t.Errorf("TailFollow() error = %v, want %v", err, stop)
+	}
+	if err = TailFollow(context.Background(), filepath.Join(dir, "missing"), nil); !os.IsNotExist(err) {
+		t.Errorf("TailFollow() error = %v, want not exist", err)
+	}
+}
@@ -20,9 +20,13 @@ package mem
 // Simple byte buffer for marshaling data.
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"os"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/pashifika/util/conv"
@@ -39,6 +43,7 @@ type FakeIO struct {
 	lastRead readOp // last read operation, so that Unread* can work correctly.
 
 	ManualReset bool // don't auto reset cache
+	MaxCap      int  // max capacity the buffer grows to by TryWrite, 0 is no limit (the other writes ignore it)
 }
 
 // The readOp constants describe the last action performed on
@@ -93,6 +98,15 @@ func (fio *FakeIO) Cap() int { return cap(fio.buf) }
 
 func (fio *FakeIO) Size() int64 { return int64(len(fio.buf)) }
 
+// Available returns how many bytes are unused in the buffer.
+func (fio *FakeIO) Available() int { return cap(fio.buf) - len(fio.buf) }
+
+// AvailableBuffer returns an empty buffer with fio.Available() capacity.
+// This buffer is intended to be appended to and
+// passed to an immediately succeeding Write call.
+// The buffer is only valid until the next write operation on fio.
+func (fio *FakeIO) AvailableBuffer() []byte { return fio.buf[len(fio.buf):] }
+
 // Truncate discards all but the first n unread bytes from the buffer
 // but continues to use the same allocated storage.
 // It panics if n is negative or greater than the length of the buffer.
@@ -108,6 +122,17 @@ func (fio *FakeIO) Truncate(n int) {
 	fio.buf = fio.buf[:int(fio.off)+n]
 }
 
+// KeepLast discards all but the last n unread bytes from the buffer
+// by advancing the read position, e.g. to keep the tail of an output.
+// It panics if n is negative or greater than the length of the buffer.
+func (fio *FakeIO) KeepLast(n int) {
+	fio.lastRead = opInvalid
+	if n < 0 || n > fio.Len() {
+		panic("bytes.FakeIO: keep last out of range")
+	}
+	fio.off = int64(len(fio.buf) - n)
+}
+
 // Reset resets the buffer to be empty,
 // but it retains the underlying storage for use by future writes.
 // Reset is the same as Truncate(0).
@@ -120,6 +145,14 @@ func (fio *FakeIO) Reset() {
 // ResetTo resets the Reader to be reading from b.
 func (fio *FakeIO) ResetTo(b []byte) { *fio = FakeIO{buf: b, off: 0, lastRead: opRead} }
 
+// ResetToCopy is like ResetTo but copies b into the storage of the buffer (reused if large enough)
+// instead of aliasing it, so the caller can keep using b. ManualReset is kept.
+func (fio *FakeIO) ResetToCopy(b []byte) {
+	fio.buf = append(fio.buf[:0], b...)
+	fio.off = 0
+	fio.lastRead = opRead
+}
+
 // tryGrowByReslice is a inlineable version of grow for the fast-case where the
 // internal buffer only needs to be resliced.
 // It returns the index where bytes should be written and whether it succeeded.
@@ -182,6 +215,21 @@ func (fio *FakeIO) Grow(n int) {
 	fio.buf = fio.buf[:m]
 }
 
+// Reserve grows the buffer by n bytes and returns the added region for the caller to
+// fill directly, the bytes are already counted as written (Len includes them).
+// The initial content of the region is unspecified, it may hold previously read data.
+// The slice is only valid until the next buffer modification.
+// If n is negative, Reserve will panic.
+// If the buffer can't grow it will panic with ErrTooLarge.
+func (fio *FakeIO) Reserve(n int) []byte {
+	if n < 0 {
+		panic("bytes.FakeIO.Reserve: negative count")
+	}
+	fio.lastRead = opInvalid
+	m := fio.grow(n)
+	return fio.buf[m : m+n : m+n]
+}
+
 // Write appends the contents of p to the buffer, growing the buffer as
 // needed. The return value n is the length of p; err is always nil. If the
 // buffer becomes too large, Write will panic with ErrTooLarge.
@@ -194,6 +242,45 @@ func (fio *FakeIO) Write(p []byte) (n int, err error) {
 	return copy(fio.buf[m:], p), nil
 }
 
+// TryWrite is like Write but never grows the capacity of the buffer past MaxCap
+// (when MaxCap > 0) and never panics for it: it writes as many bytes of p as fit,
+// the space of the already read bytes is reclaimed. If p does not fit entirely,
+// it returns the number of bytes written with io.ErrShortWrite.
+func (fio *FakeIO) TryWrite(p []byte) (n int, err error) {
+	if fio.MaxCap <= 0 {
+		return fio.Write(p)
+	}
+	fio.lastRead = opInvalid
+	m := fio.Len()
+	if room := fio.MaxCap - m; len(p) > room {
+		if room < 0 {
+			room = 0
+		}
+		p, err = p[:room], io.ErrShortWrite
+	}
+	if len(p) == 0 {
+		return 0, err
+	}
+	if i, ok := fio.tryGrowByReslice(len(p)); ok {
+		return copy(fio.buf[i:], p), err
+	}
+
+	if c := cap(fio.buf); m+len(p) <= c {
+		copy(fio.buf, fio.buf[fio.off:])
+		fio.buf = fio.buf[:m]
+	} else {
+		if c = 2*c + len(p); c > fio.MaxCap {
+			c = fio.MaxCap
+		}
+		buf := makeSlice(c)[:m]
+		copy(buf, fio.buf[fio.off:])
+		fio.buf = buf
+	}
+	fio.off = 0
+	fio.buf = append(fio.buf, p...)
+	return len(p), err
+}
+
 // WriteString appends the contents of s to the buffer, growing the buffer as
 // needed. The return value n is the length of s; err is always nil. If the
 // buffer becomes too large, WriteString will panic with ErrTooLarge.
@@ -206,6 +293,41 @@ func (fio *FakeIO) WriteString(s string) (n int, err error) {
 	return copy(fio.buf[m:], conv.StringToBytes(s)), nil
 }
 
+// Fill appends n copies of the byte c to the buffer, growing the buffer once.
+// The return value n is the number of bytes appended; err is always nil.
+// If n is negative, Fill will panic. If the buffer becomes too large,
+// Fill will panic with ErrTooLarge.
+func (fio *FakeIO) Fill(c byte, n int) (int, error) {
+	if n < 0 {
+		panic("bytes.FakeIO.Fill: negative count")
+	}
+	fio.lastRead = opInvalid
+	m, ok := fio.tryGrowByReslice(n)
+	if !ok {
+		m = fio.grow(n)
+	}
+	b := fio.buf[m:]
+	if len(b) > 0 {
+		// double the filled part each time, copy is a memmove
+		b[0] = c
+		for i := 1; i < len(b); i *= 2 {
+			copy(b[i:], b[:i])
+		}
+	}
+	return n, nil
+}
+
+// Appendf formats according to a format specifier and appends the result to the buffer,
+// growing the buffer as needed. Unlike fmt.Fprintf, it formats directly into the
+// buffer's spare capacity without an intermediate allocation.
+// The return value n is the number of bytes appended; err is always nil.
+func (fio *FakeIO) Appendf(format string, args ...any) (n int, err error) {
+	fio.lastRead = opInvalid
+	m := fio.grow(0)
+	fio.buf = fmt.Appendf(fio.buf[:m], format, args...)
+	return len(fio.buf) - m, nil
+}
+
 // MinRead is the minimum slice size passed to a Read call by
 // FakeIO.ReadFrom. As long as the FakeIO has at least MinRead bytes beyond
 // what is required to hold the contents of r, ReadFrom will not grow the
@@ -237,6 +359,35 @@ func (fio *FakeIO) ReadFrom(r io.Reader) (n int64, err error) {
 	}
 }
 
+// ReadFromN is like ReadFrom but reads at most limit bytes from r, it stops
+// without error when the limit is reached, so r may have more data left.
+// The return value n is the number of bytes read.
+func (fio *FakeIO) ReadFromN(r io.Reader, limit int64) (n int64, err error) {
+	fio.lastRead = opInvalid
+	for n < limit {
+		i := fio.grow(MinRead)
+		fio.buf = fio.buf[:i]
+		p := fio.buf[i:cap(fio.buf)]
+		if rest := limit - n; int64(len(p)) > rest {
+			p = p[:rest]
+		}
+		m, e := r.Read(p)
+		if m < 0 {
+			panic(errNegativeRead)
+		}
+
+		fio.buf = fio.buf[:i+m]
+		n += int64(m)
+		if e == io.EOF {
+			return n, nil // e is EOF, so return nil explicitly
+		}
+		if e != nil {
+			return n, e
+		}
+	}
+	return n, nil
+}
+
 // makeSlice allocates a slice of size n. If the allocation fails, it panics
 // with ErrTooLarge.
 func makeSlice(n int) []byte {
@@ -356,6 +507,25 @@ func (fio *FakeIO) Next(n int) []byte {
 	return data
 }
 
+// ReadN returns a slice containing exactly the next n bytes from the buffer,
+// advancing the buffer as if the bytes had been returned by Read.
+// If there are fewer than n bytes, it returns the remaining bytes with
+// io.ErrUnexpectedEOF, or io.EOF if the buffer is empty (as io.ReadFull).
+// The slice is only valid until the next call to a read or write method.
+func (fio *FakeIO) ReadN(n int) ([]byte, error) {
+	if n < 0 {
+		panic("bytes.FakeIO.ReadN: negative count")
+	}
+	data := fio.Next(n)
+	if len(data) < n {
+		if len(data) == 0 {
+			return data, io.EOF
+		}
+		return data, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
+
 // ReadByte reads and returns the next byte from the buffer.
 // If no byte is available, it returns error io.EOF.
 func (fio *FakeIO) ReadByte() (byte, error) {
@@ -446,6 +616,11 @@ func (fio *FakeIO) ReadBytes(delim byte) (line []byte, err error) {
 
 // readSlice is like ReadBytes but returns a reference to internal buffer data.
 func (fio *FakeIO) readSlice(delim byte) (line []byte, err error) {
+	if fio.off >= int64(len(fio.buf)) {
+		// the read position may be past the end after Seek
+		fio.lastRead = opInvalid
+		return nil, io.EOF
+	}
 	i := bytes.IndexByte(fio.buf[fio.off:], delim)
 	end := fio.off + int64(i) + 1
 	if i < 0 {
@@ -458,6 +633,90 @@ func (fio *FakeIO) readSlice(delim byte) (line []byte, err error) {
 	return line, err
 }
 
+// Records calls fn for each record of the unread portion of the buffer separated by delim,
+// the record is passed without the delimiter and the final record is delivered even if it
+// is not terminated by delim. It stops and returns the first error returned by fn.
+// The record aliases the buffer content, it is only valid until fn returns.
+func (fio *FakeIO) Records(delim byte, fn func(record []byte) error) error {
+	for {
+		line, err := fio.readSlice(delim)
+		if err == nil {
+			line = line[:len(line)-1]
+		} else if len(line) == 0 {
+			return nil
+		}
+		if e := fn(line); e != nil {
+			return e
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// IndexByteFrom returns the index of the first c in the unread portion of the buffer at or
+// after the position from (both relative to the read offset), or -1 if there is none.
+// The buffer is not advanced, so a tokenizer can look ahead without Seek.
+func (fio *FakeIO) IndexByteFrom(c byte, from int) int {
+	if from < 0 {
+		from = 0
+	}
+	if from >= fio.Len() {
+		return -1
+	}
+	i := bytes.IndexByte(fio.buf[fio.off+int64(from):], c)
+	if i < 0 {
+		return -1
+	}
+	return from + i
+}
+
+// Split consumes the unread portion of the buffer and returns its fields separated by sep,
+// like bytes.Split: the separator is not included and a trailing separator gives an empty
+// last field (unlike Records). It returns nil if the buffer is empty.
+// The fields alias the buffer content (their capacity is clipped), they are only valid
+// until the next buffer modification, as the consumed space is reused by the next write.
+func (fio *FakeIO) Split(sep byte) [][]byte {
+	if fio.empty() {
+		fio.lastRead = opInvalid
+		return nil
+	}
+	fields := bytes.Split(fio.buf[fio.off:], []byte{sep})
+	fio.off = int64(len(fio.buf))
+	fio.lastRead = opRead
+	return fields
+}
+
+// Scanner returns a bufio.Scanner reading the unread portion of the buffer with split,
+// a nil split keeps the default bufio.ScanLines. The maximum token size is raised to
+// the buffered length, so a token is never too long for the data already in memory.
+func (fio *FakeIO) Scanner(split bufio.SplitFunc) *bufio.Scanner {
+	s := bufio.NewScanner(fio)
+	if split != nil {
+		s.Split(split)
+	}
+	if n := fio.Len() + 1; n > bufio.MaxScanTokenSize {
+		s.Buffer(nil, n)
+	}
+	return s
+}
+
+// Words reads the rest of the buffer and returns its space-separated words (see bufio.ScanWords).
+func (fio *FakeIO) Words() []string { return fio.scanAll(bufio.ScanWords) }
+
+// Lines reads the rest of the buffer and returns its lines without the end-of-line
+// marker (see bufio.ScanLines).
+func (fio *FakeIO) Lines() []string { return fio.scanAll(bufio.ScanLines) }
+
+func (fio *FakeIO) scanAll(split bufio.SplitFunc) []string {
+	var tokens []string
+	s := fio.Scanner(split)
+	for s.Scan() {
+		tokens = append(tokens, s.Text())
+	}
+	return tokens
+}
+
 // ReadString reads until the first occurrence of delim in the input,
 // returning a string containing the data up to and including the delimiter.
 // If ReadString encounters an error before finding a delimiter,
@@ -485,6 +744,26 @@ func (fio *FakeIO) ReadAt(b []byte, off int64) (n int, err error) {
 	return
 }
 
+// ErrOffsetOutOfRange is returned by ReadAtStrict when the offset is beyond the size of the buffer.
+var ErrOffsetOutOfRange = errors.New("bytes.FakeIO: offset out of range")
+
+// ReadAtStrict is like ReadAt, but tells "past the end" apart from the end of data:
+// an offset equal to Size() returns (0, io.EOF) as ReadAt does, while an offset
+// greater than Size() returns (0, ErrOffsetOutOfRange) instead of io.EOF.
+func (fio *FakeIO) ReadAtStrict(b []byte, off int64) (n int, err error) {
+	if off > int64(len(fio.buf)) {
+		return 0, ErrOffsetOutOfRange
+	}
+	return fio.ReadAt(b, off)
+}
+
+// Section returns an io.SectionReader that reads the bytes [off, off+n)
+// of the buffer without copying, backed by ReadAt. The section does not
+// change the read position of fio, and it sees later writes in that range.
+func (fio *FakeIO) Section(off, n int64) *io.SectionReader {
+	return io.NewSectionReader(fio, off, n)
+}
+
 // Seek implements the io.Seeker interface.
 func (fio *FakeIO) Seek(offset int64, whence int) (int64, error) {
 	fio.lastRead = opRead
@@ -524,6 +803,43 @@ func (fio *FakeIO) Close() error {
 	return nil
 }
 
+// ReadCloser returns an io.ReadCloser reading the unread portion of the buffer,
+// its Close resets the buffer for reuse, e.g. to set the buffer as a http.Request Body.
+// Only Read and Close are exposed and the reads after Close return os.ErrClosed.
+// Close may be called concurrently with Read (as net/http does), it waits for the
+// running Read to return before resetting the buffer.
+func (fio *FakeIO) ReadCloser() io.ReadCloser {
+	return &readCloser{fio: fio, close: (*FakeIO).Reset}
+}
+
+// readCloser is the io.ReadCloser of a FakeIO, close is called once with the buffer.
+// The mutex m guards fio, so the buffer is not closed in the middle of a Read.
+type readCloser struct {
+	m     sync.Mutex
+	fio   *FakeIO
+	close func(fio *FakeIO)
+}
+
+func (rc *readCloser) Read(p []byte) (int, error) {
+	rc.m.Lock()
+	defer rc.m.Unlock()
+	if rc.fio == nil {
+		return 0, os.ErrClosed
+	}
+	return rc.fio.Read(p)
+}
+
+func (rc *readCloser) Close() error {
+	rc.m.Lock()
+	defer rc.m.Unlock()
+	if rc.fio != nil {
+		fio := rc.fio
+		rc.fio = nil
+		rc.close(fio)
+	}
+	return nil
+}
+
 // WriteAt writes a slice of bytes to a buffer starting at the position provided
 // The number of bytes written will be returned, or error. Can overwrite previous
 // written slices if the write ats overlap.
@@ -0,0 +1,158 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var errOverflow = errors.New("bytes.FakeIO: varint overflows a 64-bit integer")
+
+// WriteUvarint appends x to the buffer in the varint encoding of encoding/binary,
+// returning the number of bytes written. If the buffer becomes too large,
+// WriteUvarint will panic with ErrTooLarge.
+func (fio *FakeIO) WriteUvarint(x uint64) (n int, err error) {
+	return fio.Write(binary.AppendUvarint(fio.AvailableBuffer(), x))
+}
+
+// WriteVarint is like WriteUvarint for a signed integer (zig-zag encoded).
+func (fio *FakeIO) WriteVarint(x int64) (n int, err error) {
+	return fio.Write(binary.AppendVarint(fio.AvailableBuffer(), x))
+}
+
+// ReadUvarint reads a varint encoded by WriteUvarint (or binary.PutUvarint) from the buffer.
+// The error is io.EOF if the buffer is empty and io.ErrUnexpectedEOF if the varint
+// is truncated, the buffer is not advanced on error.
+func (fio *FakeIO) ReadUvarint() (uint64, error) {
+	if fio.empty() {
+		fio.lastRead = opInvalid
+		return 0, io.EOF
+	}
+	x, n := binary.Uvarint(fio.buf[fio.off:])
+	if err := fio.varintErr(n); err != nil {
+		return 0, err
+	}
+	fio.off += int64(n)
+	fio.lastRead = opRead
+	return x, nil
+}
+
+// ReadVarint is like ReadUvarint for a signed integer written by WriteVarint.
+func (fio *FakeIO) ReadVarint() (int64, error) {
+	if fio.empty() {
+		fio.lastRead = opInvalid
+		return 0, io.EOF
+	}
+	x, n := binary.Varint(fio.buf[fio.off:])
+	if err := fio.varintErr(n); err != nil {
+		return 0, err
+	}
+	fio.off += int64(n)
+	fio.lastRead = opRead
+	return x, nil
+}
+
+// varintErr returns the error of the binary.Uvarint result n.
+func (fio *FakeIO) varintErr(n int) error {
+	if n > 0 {
+		return nil
+	}
+	fio.lastRead = opInvalid
+	if n < 0 {
+		return errOverflow
+	}
+	return io.ErrUnexpectedEOF
+}
+
+// WriteUint16 appends v to the buffer in the byte order, err is always nil.
+// If the buffer becomes too large, WriteUint16 will panic with ErrTooLarge.
+func (fio *FakeIO) WriteUint16(order binary.ByteOrder, v uint16) error {
+	order.PutUint16(fio.buf[fio.growFixed(2):], v)
+	return nil
+}
+
+// WriteUint32 appends v to the buffer in the byte order, err is always nil.
+// If the buffer becomes too large, WriteUint32 will panic with ErrTooLarge.
+func (fio *FakeIO) WriteUint32(order binary.ByteOrder, v uint32) error {
+	order.PutUint32(fio.buf[fio.growFixed(4):], v)
+	return nil
+}
+
+// WriteUint64 appends v to the buffer in the byte order, err is always nil.
+// If the buffer becomes too large, WriteUint64 will panic with ErrTooLarge.
+func (fio *FakeIO) WriteUint64(order binary.ByteOrder, v uint64) error {
+	order.PutUint64(fio.buf[fio.growFixed(8):], v)
+	return nil
+}
+
+// ReadUint16 reads an integer written in the byte order from the buffer.
+// The error is io.EOF if the buffer is empty and io.ErrUnexpectedEOF if it has
+// fewer than 2 bytes, the buffer is not advanced on error.
+func (fio *FakeIO) ReadUint16(order binary.ByteOrder) (uint16, error) {
+	b, err := fio.readFixed(2)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint16(b), nil
+}
+
+// ReadUint32 is like ReadUint16 for a 4 bytes integer.
+func (fio *FakeIO) ReadUint32(order binary.ByteOrder) (uint32, error) {
+	b, err := fio.readFixed(4)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint32(b), nil
+}
+
+// ReadUint64 is like ReadUint16 for a 8 bytes integer.
+func (fio *FakeIO) ReadUint64(order binary.ByteOrder) (uint64, error) {
+	b, err := fio.readFixed(8)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint64(b), nil
+}
+
+// growFixed grows the buffer by n bytes and returns the index where they should be written.
+func (fio *FakeIO) growFixed(n int) int {
+	fio.lastRead = opInvalid
+	m, ok := fio.tryGrowByReslice(n)
+	if !ok {
+		m = fio.grow(n)
+	}
+	return m
+}
+
+// readFixed returns the next n bytes of the buffer, or an error without advancing it.
+func (fio *FakeIO) readFixed(n int) ([]byte, error) {
+	if fio.empty() {
+		fio.lastRead = opInvalid
+		return nil, io.EOF
+	}
+	if fio.Len() < n {
+		fio.lastRead = opInvalid
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := fio.buf[fio.off : fio.off+int64(n)]
+	fio.off += int64(n)
+	fio.lastRead = opRead
+	return b, nil
+}
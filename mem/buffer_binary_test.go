@@ -0,0 +1,137 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestFakeIO_Uvarint(t *testing.T) {
+	tests := []uint64{0, 1, 127, 128, 255, 16383, 16384, math.MaxUint32, math.MaxUint64}
+	fio := NewFakeIO(nil)
+	for _, x := range tests {
+		n, err := fio.WriteUvarint(x)
+		if err != nil || n != len(binary.AppendUvarint(nil, x)) {
+			t.Fatalf("WriteUvarint(%d) = %d, %v", x, n, err)
+		}
+	}
+	for _, want := range tests {
+		got, err := fio.ReadUvarint()
+		if err != nil || got != want {
+			t.Errorf("ReadUvarint() = %d, %v, want %d", got, err, want)
+		}
+	}
+	if _, err := fio.ReadUvarint(); err != io.EOF {
+		t.Errorf("ReadUvarint() error = %v, want %v", err, io.EOF)
+	}
+	if _, err := fio.Seek(10, io.SeekEnd); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fio.ReadUvarint(); err != io.EOF {
+		t.Errorf("ReadUvarint() after seek error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestFakeIO_Varint(t *testing.T) {
+	tests := []int64{0, 1, -1, 63, -64, 64, -65, 127, 128, math.MaxInt64, math.MinInt64}
+	fio := NewFakeIO(nil)
+	for _, x := range tests {
+		if _, err := fio.WriteVarint(x); err != nil {
+			t.Fatalf("WriteVarint(%d) error = %v", x, err)
+		}
+	}
+	for _, want := range tests {
+		got, err := fio.ReadVarint()
+		if err != nil || got != want {
+			t.Errorf("ReadVarint() = %d, %v, want %d", got, err, want)
+		}
+	}
+	if _, err := fio.ReadVarint(); err != io.EOF {
+		t.Errorf("ReadVarint() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestFakeIO_ReadUvarintErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "empty", data: nil, wantErr: io.EOF},
+		{name: "truncated", data: []byte{0x80, 0x80}, wantErr: io.ErrUnexpectedEOF},
+		{name: "overflow", data: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIO(tt.data)
+			_, err := fio.ReadUvarint()
+			if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+				t.Errorf("ReadUvarint() error = %v, want %v", err, tt.wantErr)
+			}
+			if fio.Len() != len(tt.data) {
+				t.Errorf("ReadUvarint() advanced the buffer on error, len = %d, want %d", fio.Len(), len(tt.data))
+			}
+		})
+	}
+}
+
+func TestFakeIO_FixedWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		order binary.ByteOrder
+		want  []byte
+	}{
+		{name: "big endian", order: binary.BigEndian, want: []byte{
+			0x01, 0x02, 0x01, 0x02, 0x03, 0x04, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}},
+		{name: "little endian", order: binary.LittleEndian, want: []byte{
+			0x02, 0x01, 0x04, 0x03, 0x02, 0x01, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIO(nil)
+			_ = fio.WriteUint16(tt.order, 0x0102)
+			_ = fio.WriteUint32(tt.order, 0x01020304)
+			_ = fio.WriteUint64(tt.order, 0x0102030405060708)
+			if got := fio.Bytes(); string(got) != string(tt.want) {
+				t.Fatalf("WriteUint*() = % x, want % x", got, tt.want)
+			}
+			if v, err := fio.ReadUint16(tt.order); err != nil || v != 0x0102 {
+				t.Errorf("ReadUint16() = %#x, %v", v, err)
+			}
+			if v, err := fio.ReadUint32(tt.order); err != nil || v != 0x01020304 {
+				t.Errorf("ReadUint32() = %#x, %v", v, err)
+			}
+			if v, err := fio.ReadUint64(tt.order); err != nil || v != 0x0102030405060708 {
+				t.Errorf("ReadUint64() = %#x, %v", v, err)
+			}
+			if _, err := fio.ReadUint16(tt.order); err != io.EOF {
+				t.Errorf("ReadUint16() error = %v, want %v", err, io.EOF)
+			}
+		})
+	}
+
+	fio := NewFakeIO([]byte{1, 2, 3})
+	if _, err := fio.ReadUint32(binary.BigEndian); err != io.ErrUnexpectedEOF || fio.Len() != 3 {
+		t.Errorf("ReadUint32() error = %v, len = %d, want %v, 3", err, fio.Len(), io.ErrUnexpectedEOF)
+	}
+}
@@ -18,9 +18,14 @@
 package mem_test
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"unicode/utf8"
 
 	. "github.com/pashifika/util/mem"
@@ -396,3 +401,454 @@ func TestFakeIO_ReadString(t *testing.T) {
 		})
 	}
 }
+
+func TestFakeIO_Section(t *testing.T) {
+	type args struct {
+		off int64
+		n   int64
+	}
+	tests := []struct {
+		name   string
+		args   args
+		result string
+	}{
+		{name: "middle", args: args{off: 3, n: 4}, result: "3456"},
+		{name: "head", args: args{off: 0, n: 2}, result: "01"},
+		{name: "over tail", args: args{off: 8, n: 10}, result: "89"},
+		{name: "out of range", args: args{off: 20, n: 5}, result: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIOString("0123456789")
+			got, err := io.ReadAll(fio.Section(tt.args.off, tt.args.n))
+			if err != nil {
+				t.Errorf("ReadAll() error = %v", err)
+				return
+			}
+			if string(got) != tt.result {
+				t.Errorf("Section() = %q, want %q", got, tt.result)
+			}
+			if fio.Len() != 10 {
+				t.Errorf("Len() = %d, want the read position unchanged", fio.Len())
+			}
+		})
+	}
+}
+
+func TestFakeIO_KeepLast(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		result string
+		panic  bool
+	}{
+		{name: "tail", n: 4, result: "6789"},
+		{name: "all", n: 10, result: "0123456789"},
+		{name: "none", n: 0, result: ""},
+		{name: "too long", n: 11, panic: true},
+		{name: "negative", n: -1, panic: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.panic {
+					t.Errorf("KeepLast() panic = %v, want %v", r, tt.panic)
+				}
+			}()
+			fio := NewFakeIOString("0123456789")
+			fio.KeepLast(tt.n)
+			if fio.String() != tt.result {
+				t.Errorf("KeepLast() = %q, want %q", fio.String(), tt.result)
+			}
+			// keep writing after the tail
+			_, _ = fio.WriteString("ab")
+			if fio.String() != tt.result+"ab" {
+				t.Errorf("WriteString() = %q, want %q", fio.String(), tt.result+"ab")
+			}
+		})
+	}
+}
+
+func TestFakeIO_ReadAtStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		off     int64
+		result  string
+		wantErr error
+	}{
+		{name: "inside", off: 2, result: "3456", wantErr: nil},
+		{name: "short tail", off: 4, result: "56", wantErr: io.EOF},
+		{name: "exactly len", off: 6, result: "", wantErr: io.EOF},
+		{name: "past len", off: 7, result: "", wantErr: ErrOffsetOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := make([]byte, 4)
+			fio := NewFakeIOString("123456")
+			gotN, err := fio.ReadAtStrict(buf, tt.off)
+			if err != tt.wantErr {
+				t.Errorf("ReadAtStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if str := string(buf[:gotN]); str != tt.result {
+				t.Errorf("ReadAtStrict() = %q, want %q", str, tt.result)
+			}
+
+			sfio := NewSyncFakeIOString("123456")
+			gotN, err = sfio.ReadAtStrict(buf, tt.off)
+			if err != tt.wantErr {
+				t.Errorf("SyncFakeIO.ReadAtStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if str := string(buf[:gotN]); str != tt.result {
+				t.Errorf("SyncFakeIO.ReadAtStrict() = %q, want %q", str, tt.result)
+			}
+		})
+	}
+}
+
+func TestFakeIO_Records(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		result []string
+	}{
+		{name: "no trailing newline", data: "rec1\nrec2\nrec3", result: []string{"rec1", "rec2", "rec3"}},
+		{name: "trailing newline", data: "rec1\nrec2\nrec3\n", result: []string{"rec1", "rec2", "rec3"}},
+		{name: "empty record", data: "rec1\n\nrec3", result: []string{"rec1", "", "rec3"}},
+		{name: "empty", data: "", result: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIOString(tt.data)
+			var got []string
+			err := fio.Records('\n', func(record []byte) error {
+				got = append(got, string(record))
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Records() error = %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.result) || len(got) != len(tt.result) {
+				t.Errorf("Records() = %q, want %q", got, tt.result)
+			}
+		})
+	}
+
+	stop := io.ErrUnexpectedEOF
+	calls := 0
+	err := NewFakeIOString("a\nb\nc").Records('\n', func([]byte) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Records() error = %v, calls = %d, want %v, 1", err, calls, stop)
+	}
+}
+
+func TestFakeIO_ReadStringAfterSeek(t *testing.T) {
+	fio := NewFakeIOString("abc")
+	_, _ = fio.Seek(10, io.SeekStart)
+	line, err := fio.ReadString('\n')
+	if line != "" || err != io.EOF {
+		t.Errorf("ReadString() = %q, %v, want \"\", io.EOF", line, err)
+	}
+}
+
+func TestFakeIO_Scanner(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		words []string
+		lines []string
+	}{
+		{name: "single line", data: "foo bar  baz", words: []string{"foo", "bar", "baz"}, lines: []string{"foo bar  baz"}},
+		{name: "multi line", data: " a b\r\nc\n", words: []string{"a", "b", "c"}, lines: []string{" a b", "c"}},
+		{name: "empty", data: "", words: nil, lines: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewFakeIOString(tt.data).Words(); fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.words) {
+				t.Errorf("Words() = %q, want %q", got, tt.words)
+			}
+			if got := NewFakeIOString(tt.data).Lines(); fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.lines) {
+				t.Errorf("Lines() = %q, want %q", got, tt.lines)
+			}
+		})
+	}
+
+	long := strings.Repeat("x", bufio.MaxScanTokenSize*2)
+	fio := NewFakeIOString("a " + long)
+	s := fio.Scanner(bufio.ScanWords)
+	var got []string
+	for s.Scan() {
+		got = append(got, s.Text())
+	}
+	if err := s.Err(); err != nil || len(got) != 2 || got[1] != long {
+		t.Errorf("Scanner() tokens = %d, err = %v, want 2 tokens", len(got), err)
+	}
+}
+
+func TestFakeIO_ReadN(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		n       int
+		result  string
+		wantErr error
+	}{
+		{name: "exact", data: "abcd", n: 4, result: "abcd"},
+		{name: "over", data: "abcdef", n: 4, result: "abcd"},
+		{name: "under", data: "ab", n: 4, result: "ab", wantErr: io.ErrUnexpectedEOF},
+		{name: "empty", data: "", n: 4, result: "", wantErr: io.EOF},
+		{name: "zero", data: "", n: 0, result: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIOString(tt.data)
+			got, err := fio.ReadN(tt.n)
+			if err != tt.wantErr {
+				t.Errorf("ReadN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.result {
+				t.Errorf("ReadN() = %q, want %q", got, tt.result)
+			}
+			if want := len(tt.data) - len(tt.result); fio.Len() != want {
+				t.Errorf("ReadN() remaining = %d, want %d", fio.Len(), want)
+			}
+		})
+	}
+}
+
+func TestFakeIO_ReadFromN(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		limit   int64
+		wantN   int64
+		wantErr bool
+	}{
+		{name: "oversized reader", size: 10000, limit: 1000, wantN: 1000},
+		{name: "smaller reader", size: 100, limit: 1000, wantN: 100},
+		{name: "exact", size: 1000, limit: 1000, wantN: 1000},
+		{name: "zero limit", size: 100, limit: 0, wantN: 0},
+		{name: "reader error", size: 100, limit: 1000, wantN: 100, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Repeat("x", tt.size)
+			var r io.Reader = strings.NewReader(data)
+			if tt.wantErr {
+				r = io.MultiReader(r, iotest.ErrReader(io.ErrUnexpectedEOF))
+			}
+			fio := NewFakeIOString("head")
+			n, err := fio.ReadFromN(r, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadFromN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n != tt.wantN || fio.String() != "head"+data[:n] {
+				t.Errorf("ReadFromN() n = %d, len = %d, want %d", n, fio.Len(), tt.wantN)
+			}
+		})
+	}
+}
+
+func TestFakeIO_Reserve(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		n      int
+		result string
+	}{
+		{name: "empty buffer", data: "", n: 3, result: "xxx"},
+		{name: "append", data: "abc", n: 2, result: "abcxx"},
+		{name: "grow", data: "abc", n: 1000, result: "abc" + strings.Repeat("x", 1000)},
+		{name: "zero", data: "abc", n: 0, result: "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIOString(tt.data)
+			b := fio.Reserve(tt.n)
+			if len(b) != tt.n || cap(b) != tt.n {
+				t.Fatalf("Reserve() len = %d, cap = %d, want %d", len(b), cap(b), tt.n)
+			}
+			for i := range b {
+				b[i] = 'x'
+			}
+			if got := fio.String(); got != tt.result {
+				t.Errorf("Reserve() buffer = %q, want %q", got, tt.result)
+			}
+		})
+	}
+
+	fio := NewFakeIOString("abc")
+	_, _ = fio.ReadByte()
+	copy(fio.Reserve(2), "de")
+	_, _ = fio.WriteString("f")
+	if got := fio.String(); got != "bcdef" {
+		t.Errorf("Reserve() after read = %q, want %q", got, "bcdef")
+	}
+}
+
+func TestFakeIO_ResetToCopy(t *testing.T) {
+	tests := []struct {
+		name string
+		init []byte
+		data string
+	}{
+		{name: "empty buffer", init: nil, data: "hello"},
+		{name: "reuse storage", init: make([]byte, 0, 64), data: "hello"},
+		{name: "empty input", init: []byte("old"), data: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIO(tt.init)
+			b := []byte(tt.data)
+			fio.ResetToCopy(b)
+			for i := range b {
+				b[i] = 'x'
+			}
+			if got := fio.String(); got != tt.data {
+				t.Errorf("ResetToCopy() buffer = %q, want %q", got, tt.data)
+			}
+			_, _ = fio.WriteString("!")
+			if string(b) != strings.Repeat("x", len(tt.data)) {
+				t.Errorf("ResetToCopy() write modified the input = %q", b)
+			}
+		})
+	}
+
+	b := make([]byte, 3, 8)
+	copy(b, "abc")
+	fio := NewFakeIO(nil)
+	fio.ResetTo(b)
+	_, _ = fio.WriteString("d")
+	if got := string(b[:4]); got != "abcd" {
+		t.Errorf("ResetTo() should alias the input = %q, want %q", got, "abcd")
+	}
+}
+
+func TestFakeIO_ReadCloser(t *testing.T) {
+	fio := NewFakeIOString("hello world")
+	_ = fio.Next(6)
+	rc := fio.ReadCloser()
+	got, err := io.ReadAll(rc)
+	if err != nil || string(got) != "world" {
+		t.Errorf("ReadCloser() read = %q, %v, want %q", got, err, "world")
+	}
+	if err = rc.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if fio.Len() != 0 || fio.Size() != 0 {
+		t.Errorf("Close() did not reset the buffer, len = %d, size = %d", fio.Len(), fio.Size())
+	}
+	if _, err = rc.Read(make([]byte, 1)); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Read() after Close error = %v, want %v", err, os.ErrClosed)
+	}
+	if err = rc.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
+
+func TestFakeIO_TryWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		read    int
+		maxCap  int
+		write   string
+		result  string
+		wantN   int
+		wantErr error
+	}{
+		{name: "no limit", data: "abc", maxCap: 0, write: "defgh", result: "abcdefgh", wantN: 5},
+		{name: "below cap", data: "abc", maxCap: 16, write: "def", result: "abcdef", wantN: 3},
+		{name: "at cap", data: "abc", maxCap: 6, write: "def", result: "abcdef", wantN: 3},
+		{name: "beyond cap", data: "abc", maxCap: 6, write: "defgh", result: "abcdef", wantN: 3, wantErr: io.ErrShortWrite},
+		{name: "full", data: "abcdef", maxCap: 6, write: "g", result: "abcdef", wantN: 0, wantErr: io.ErrShortWrite},
+		{name: "reclaim read space", data: "abcdef", read: 4, maxCap: 6, write: "ghijk", result: "efghij", wantN: 4, wantErr: io.ErrShortWrite},
+		{name: "large write", data: "", maxCap: 1000, write: strings.Repeat("x", 2000), result: strings.Repeat("x", 1000), wantN: 1000, wantErr: io.ErrShortWrite},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := make([]byte, len(tt.data))
+			copy(buf, tt.data)
+			fio := NewFakeIO(buf)
+			fio.MaxCap = tt.maxCap
+			_ = fio.Next(tt.read)
+			n, err := fio.TryWrite([]byte(tt.write))
+			if n != tt.wantN || err != tt.wantErr {
+				t.Errorf("TryWrite() = %d, %v, want %d, %v", n, err, tt.wantN, tt.wantErr)
+			}
+			if got := fio.String(); got != tt.result {
+				t.Errorf("TryWrite() buffer = %q, want %q", got, tt.result)
+			}
+			if tt.maxCap > 0 && fio.Cap() > tt.maxCap {
+				t.Errorf("TryWrite() cap = %d, want <= %d", fio.Cap(), tt.maxCap)
+			}
+		})
+	}
+}
+
+func TestFakeIO_Split(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		read   int
+		result []string
+	}{
+		{name: "fields", data: "a,bb,ccc", result: []string{"a", "bb", "ccc"}},
+		{name: "trailing separator", data: "a,b,", result: []string{"a", "b", ""}},
+		{name: "empty fields", data: ",a,,b", result: []string{"", "a", "", "b"}},
+		{name: "no separator", data: "abc", result: []string{"abc"}},
+		{name: "after read", data: "x,a,b", read: 2, result: []string{"a", "b"}},
+		{name: "empty", data: "", result: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIOString(tt.data)
+			_ = fio.Next(tt.read)
+			fields := fio.Split(',')
+			var got []string
+			for _, f := range fields {
+				got = append(got, string(f))
+			}
+			if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.result) {
+				t.Errorf("Split() = %q, want %q", got, tt.result)
+			}
+			if fio.Len() != 0 {
+				t.Errorf("Split() did not consume the buffer, len = %d", fio.Len())
+			}
+		})
+	}
+}
+
+func TestFakeIO_IndexByteFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		read int
+		from int
+		want int
+	}{
+		{name: "from start", data: "a,b,c", from: 0, want: 1},
+		{name: "at match", data: "a,b,c", from: 1, want: 1},
+		{name: "after match", data: "a,b,c", from: 2, want: 3},
+		{name: "relative to read offset", data: "x,a,b", read: 2, from: 0, want: 1},
+		{name: "not found", data: "a,b,c", from: 4, want: -1},
+		{name: "from == len", data: "a,b", from: 3, want: -1},
+		{name: "from beyond len", data: "a,b", from: 10, want: -1},
+		{name: "negative from", data: "a,b", from: -1, want: 1},
+		{name: "empty", data: "", from: 0, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIOString(tt.data)
+			_ = fio.Next(tt.read)
+			if got := fio.IndexByteFrom(',', tt.from); got != tt.want {
+				t.Errorf("IndexByteFrom() = %d, want %d", got, tt.want)
+			}
+			if fio.Len() != len(tt.data)-tt.read {
+				t.Errorf("IndexByteFrom() advanced the buffer, len = %d", fio.Len())
+			}
+		})
+	}
+}
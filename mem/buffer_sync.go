@@ -23,7 +23,9 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"github.com/pashifika/util/conv"
@@ -37,9 +39,28 @@ type SyncFakeIO struct {
 	off      int64  // read at &buf[off], write at &buf[len(buf)]
 	lastRead readOp // last read operation, so that Unread* can work correctly.
 
+	cond     *sync.Cond  // wakes up BlockingRead, created on first use
+	deadline time.Time   // read deadline of BlockingRead, zero means none
+	timer    *time.Timer // fires when the deadline passes
+
 	ManualReset bool // don't auto reset cache
 }
 
+// waiter returns the condition used by BlockingRead, fio.m must be locked.
+func (fio *SyncFakeIO) waiter() *sync.Cond {
+	if fio.cond == nil {
+		fio.cond = sync.NewCond(&fio.m)
+	}
+	return fio.cond
+}
+
+// signal wakes up all pending BlockingRead calls, fio.m must be locked.
+func (fio *SyncFakeIO) signal() {
+	if fio.cond != nil {
+		fio.cond.Broadcast()
+	}
+}
+
 // Bytes returns a slice of length b.Len() holding the unread portion of the buffer.
 // The slice is valid for use only until the next buffer modification (that is,
 // only until the next call to a method like Read, Write, Reset, or Truncate).
@@ -52,17 +73,19 @@ func (fio *SyncFakeIO) Bytes() []byte {
 	return b
 }
 
-// String returns the contents of the unread portion of the buffer
+// String returns a copy of the unread portion of the buffer
 // as a string. If the SyncFakeIO is a nil pointer, it returns "<nil>".
 //
-// To build strings more efficiently, see the strings.Builder type.
+// Unlike FakeIO.String, the bytes are copied under the lock: the string must
+// stay immutable after the lock is released, while other goroutines may keep
+// writing to (or resetting) the same storage.
 func (fio *SyncFakeIO) String() string {
 	if fio == nil {
 		// Special case, useful in debugging.
 		return "<nil>"
 	}
 	fio.m.RLock()
-	str := conv.BytesToString(fio.buf[fio.off:])
+	str := string(fio.buf[fio.off:])
 	fio.m.RUnlock()
 	return str
 }
@@ -88,6 +111,28 @@ func (fio *SyncFakeIO) Size() int64 {
 	return size
 }
 
+// Available returns how many bytes are unused in the buffer.
+func (fio *SyncFakeIO) Available() int {
+	fio.m.RLock()
+	n := cap(fio.buf) - len(fio.buf)
+	fio.m.RUnlock()
+	return n
+}
+
+// AvailableBuffer returns an empty buffer with fio.Available() capacity.
+// This buffer is intended to be appended to and
+// passed to an immediately succeeding Write call.
+// The lock is not held between the two calls, so the append then Write is only valid
+// while no other goroutine writes to fio (or truncates or resets it): with several
+// writers, another Write may overwrite or reallocate the appended bytes, use Write instead.
+// Concurrent readers are fine.
+func (fio *SyncFakeIO) AvailableBuffer() []byte {
+	fio.m.RLock()
+	b := fio.buf[len(fio.buf):]
+	fio.m.RUnlock()
+	return b
+}
+
 // Len returns the number of bytes of the unread portion of the buffer;
 // b.Len() == len(b.Bytes()).
 func (fio *SyncFakeIO) Len() int {
@@ -97,6 +142,17 @@ func (fio *SyncFakeIO) Len() int {
 	return n
 }
 
+// Snapshot returns Len, Cap, Size and the read offset of the buffer under a single lock,
+// so the values are coherent with each other (len <= size <= cap, len == size-off)
+// even while other goroutines are writing.
+func (fio *SyncFakeIO) Snapshot() (length, capacity int, size, off int64) {
+	fio.m.RLock()
+	length, capacity = fio.len(), cap(fio.buf)
+	size, off = int64(len(fio.buf)), fio.off
+	fio.m.RUnlock()
+	return
+}
+
 // Truncate discards all but the first n unread bytes from the buffer
 // but continues to use the same allocated storage.
 // It panics if n is negative or greater than the length of the buffer.
@@ -115,6 +171,19 @@ func (fio *SyncFakeIO) Truncate(n int) {
 	fio.buf = fio.buf[:int(fio.off)+n]
 }
 
+// KeepLast discards all but the last n unread bytes from the buffer
+// by advancing the read position, e.g. to keep the tail of an output.
+// It panics if n is negative or greater than the length of the buffer.
+func (fio *SyncFakeIO) KeepLast(n int) {
+	fio.m.Lock()
+	defer fio.m.Unlock()
+	fio.lastRead = opInvalid
+	if n < 0 || n > fio.len() {
+		panic("bytes.SyncFakeIO: keep last out of range")
+	}
+	fio.off = int64(len(fio.buf) - n)
+}
+
 func (fio *SyncFakeIO) reset() {
 	fio.buf = fio.buf[:0]
 	fio.off = 0
@@ -130,8 +199,28 @@ func (fio *SyncFakeIO) Reset() {
 	fio.m.Unlock()
 }
 
-// ResetTo resets the Reader to be reading from b.
-func (fio *SyncFakeIO) ResetTo(b []byte) { *fio = SyncFakeIO{buf: b, off: 0, lastRead: opRead} }
+// ResetTo resets the Reader to be reading from b and clears ManualReset like FakeIO.ResetTo.
+// The read deadline is kept and the pending BlockingRead calls are woken up.
+func (fio *SyncFakeIO) ResetTo(b []byte) {
+	fio.m.Lock()
+	defer fio.m.Unlock()
+	defer fio.signal()
+	fio.buf = b
+	fio.off = 0
+	fio.lastRead = opRead
+	fio.ManualReset = false
+}
+
+// ResetToCopy is like ResetTo but copies b into the storage of the buffer (reused if large enough)
+// instead of aliasing it, so the caller can keep using b. ManualReset is kept.
+func (fio *SyncFakeIO) ResetToCopy(b []byte) {
+	fio.m.Lock()
+	defer fio.m.Unlock()
+	defer fio.signal()
+	fio.buf = append(fio.buf[:0], b...)
+	fio.off = 0
+	fio.lastRead = opRead
+}
 
 // tryGrowByReslice is a inlineable version of grow for the fast-case where the
 // internal buffer only needs to be resliced.
@@ -208,6 +297,7 @@ func (fio *SyncFakeIO) Write(p []byte) (n int, err error) {
 	if !ok {
 		m = fio.grow(len(p))
 	}
+	defer fio.signal()
 	return copy(fio.buf[m:], p), nil
 }
 
@@ -222,6 +312,7 @@ func (fio *SyncFakeIO) WriteString(s string) (n int, err error) {
 	if !ok {
 		m = fio.grow(len(s))
 	}
+	defer fio.signal()
 	return copy(fio.buf[m:], conv.StringToBytes(s)), nil
 }
 
@@ -232,6 +323,7 @@ func (fio *SyncFakeIO) WriteString(s string) (n int, err error) {
 func (fio *SyncFakeIO) ReadFrom(r io.Reader) (n int64, err error) {
 	fio.m.Lock()
 	defer fio.m.Unlock()
+	defer fio.signal()
 	fio.lastRead = opInvalid
 	for {
 		i := fio.grow(MinRead)
@@ -252,6 +344,38 @@ func (fio *SyncFakeIO) ReadFrom(r io.Reader) (n int64, err error) {
 	}
 }
 
+// ReadFromN is like ReadFrom but reads at most limit bytes from r, it stops
+// without error when the limit is reached, so r may have more data left.
+// The return value n is the number of bytes read.
+func (fio *SyncFakeIO) ReadFromN(r io.Reader, limit int64) (n int64, err error) {
+	fio.m.Lock()
+	defer fio.m.Unlock()
+	defer fio.signal()
+	fio.lastRead = opInvalid
+	for n < limit {
+		i := fio.grow(MinRead)
+		fio.buf = fio.buf[:i]
+		p := fio.buf[i:cap(fio.buf)]
+		if rest := limit - n; int64(len(p)) > rest {
+			p = p[:rest]
+		}
+		m, e := r.Read(p)
+		if m < 0 {
+			panic(errNegativeRead)
+		}
+
+		fio.buf = fio.buf[:i+m]
+		n += int64(m)
+		if e == io.EOF {
+			return n, nil // e is EOF, so return nil explicitly
+		}
+		if e != nil {
+			return n, e
+		}
+	}
+	return n, nil
+}
+
 // WriteTo writes data to w until the buffer is drained or an error occurs.
 // The return value n is the number of bytes written; it always fits into an
 // int, but it is int64 to match the io.WriterTo interface. Any error
@@ -301,6 +425,7 @@ func (fio *SyncFakeIO) writeByte(c byte) error {
 		m = fio.grow(1)
 	}
 	fio.buf[m] = c
+	fio.signal()
 	return nil
 }
 
@@ -324,6 +449,7 @@ func (fio *SyncFakeIO) WriteRune(r rune) (n int, err error) {
 	}
 	n = utf8.EncodeRune(fio.buf[m:m+utf8.UTFMax], r)
 	fio.buf = fio.buf[:m+n]
+	fio.signal()
 	fio.m.Unlock()
 	return n, nil
 }
@@ -357,6 +483,50 @@ func (fio *SyncFakeIO) Read(p []byte) (n int, err error) {
 	return n, nil
 }
 
+// BlockingRead is like Read, but when the buffer is empty it waits until
+// another goroutine writes data or the read deadline passes.
+// If the deadline is exceeded, it returns os.ErrDeadlineExceeded.
+func (fio *SyncFakeIO) BlockingRead(p []byte) (n int, err error) {
+	fio.m.Lock()
+	defer fio.m.Unlock()
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for fio.empty() {
+		if !fio.deadline.IsZero() && !time.Now().Before(fio.deadline) {
+			return 0, os.ErrDeadlineExceeded
+		}
+		fio.waiter().Wait()
+	}
+	n = copy(p, fio.buf[fio.off:])
+	fio.off += int64(n)
+	fio.lastRead = opRead
+	return n, nil
+}
+
+// SetReadDeadline sets the deadline for pending and future BlockingRead calls,
+// like net.Conn. A zero value for t means BlockingRead will not time out.
+// The returned error is always nil.
+func (fio *SyncFakeIO) SetReadDeadline(t time.Time) error {
+	fio.m.Lock()
+	defer fio.m.Unlock()
+	if fio.timer != nil {
+		fio.timer.Stop()
+		fio.timer = nil
+	}
+	fio.deadline = t
+	if !t.IsZero() {
+		fio.timer = time.AfterFunc(time.Until(t), func() {
+			fio.m.Lock()
+			fio.signal()
+			fio.m.Unlock()
+		})
+	}
+	// let the pending reads check the new deadline
+	fio.signal()
+	return nil
+}
+
 // Next returns a slice containing the next n bytes from the buffer,
 // advancing the buffer as if the bytes had been returned by Read.
 // If there are fewer than n bytes in the buffer, Next returns the entire buffer.
@@ -480,6 +650,11 @@ func (fio *SyncFakeIO) ReadBytes(delim byte) (line []byte, err error) {
 
 // readSlice is like ReadBytes but returns a reference to internal buffer data.
 func (fio *SyncFakeIO) readSlice(delim byte) (line []byte, err error) {
+	if fio.off >= int64(len(fio.buf)) {
+		// the read position may be past the end after Seek
+		fio.lastRead = opInvalid
+		return nil, io.EOF
+	}
 	i := bytes.IndexByte(fio.buf[fio.off:], delim)
 	end := fio.off + int64(i) + 1
 	if i < 0 {
@@ -524,6 +699,28 @@ func (fio *SyncFakeIO) ReadAt(b []byte, off int64) (n int, err error) {
 	return
 }
 
+// ReadAtStrict is like ReadAt, but tells "past the end" apart from the end of data:
+// an offset equal to Size() returns (0, io.EOF) as ReadAt does, while an offset
+// greater than Size() returns (0, ErrOffsetOutOfRange) instead of io.EOF.
+func (fio *SyncFakeIO) ReadAtStrict(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("SyncFakeIO.Multi.ReadAt: negative offset")
+	}
+	fio.m.RLock()
+	defer fio.m.RUnlock()
+	if off > int64(len(fio.buf)) {
+		return 0, ErrOffsetOutOfRange
+	}
+	if off == int64(len(fio.buf)) {
+		return 0, io.EOF
+	}
+	n = copy(b, fio.buf[off:])
+	if n < len(b) {
+		err = io.EOF
+	}
+	return
+}
+
 // Seek implements the io.Seeker interface.
 func (fio *SyncFakeIO) Seek(offset int64, whence int) (int64, error) {
 	fio.m.Lock()
@@ -587,6 +784,7 @@ func (fio *SyncFakeIO) WriteAt(p []byte, pos int64) (n int, err error) {
 		fio.buf = fio.buf[:expLen]
 	}
 	copy(fio.buf[pos:], p)
+	fio.signal()
 	fio.m.Unlock()
 	return pLen, nil
 }
@@ -0,0 +1,238 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestSyncFakeIO_BlockingRead(t *testing.T) {
+	fio := &SyncFakeIO{}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = fio.WriteString("hello")
+	}()
+	buf := make([]byte, 8)
+	n, err := fio.BlockingRead(buf)
+	if err != nil {
+		t.Fatalf("BlockingRead() error = %v", err)
+	}
+	if got := string(buf[:n]); got != "hello" {
+		t.Errorf("BlockingRead() = %q, want %q", got, "hello")
+	}
+}
+
+func TestSyncFakeIO_ResetToWakesBlockingRead(t *testing.T) {
+	fio := &SyncFakeIO{}
+	type result struct {
+		s   string
+		err error
+	}
+	res := make(chan result, 1)
+	go func() {
+		buf := make([]byte, 8)
+		n, err := fio.BlockingRead(buf)
+		res <- result{s: string(buf[:n]), err: err}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	fio.ResetTo([]byte("reset"))
+	select {
+	case got := <-res:
+		if got.err != nil || got.s != "reset" {
+			t.Errorf("BlockingRead() = %q, %v, want %q", got.s, got.err, "reset")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("BlockingRead() not woken up by ResetTo")
+	}
+
+	// the deadline is kept
+	_ = fio.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	fio.ResetTo(nil)
+	if _, err := fio.BlockingRead(make([]byte, 8)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("BlockingRead() after ResetTo error = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+}
+
+func TestResetToClearsManualReset(t *testing.T) {
+	fio := NewFakeIOString("abc")
+	fio.ManualReset = true
+	fio.ResetTo([]byte("xyz"))
+	if fio.ManualReset || fio.String() != "xyz" {
+		t.Errorf("FakeIO.ResetTo() = %q, ManualReset = %v, want %q, false", fio.String(), fio.ManualReset, "xyz")
+	}
+
+	sfio := &SyncFakeIO{ManualReset: true}
+	_, _ = sfio.WriteString("abc")
+	sfio.ResetTo([]byte("xyz"))
+	if sfio.ManualReset || sfio.String() != "xyz" {
+		t.Errorf("SyncFakeIO.ResetTo() = %q, ManualReset = %v, want %q, false", sfio.String(), sfio.ManualReset, "xyz")
+	}
+}
+
+func TestSyncFakeIO_AvailableBufferSingleWriter(t *testing.T) {
+	fio := NewSyncFakeIO(make([]byte, 0, 64))
+	const n = 1000
+	go func() {
+		for i := 0; i < n; i++ {
+			b := strconv.AppendInt(fio.AvailableBuffer(), int64(i), 10)
+			_, _ = fio.Write(append(b, '\n'))
+		}
+	}()
+
+	// a concurrent reader does not break the append then Write of the single writer
+	got := make([]byte, 0, 8*n)
+	buf := make([]byte, 16)
+	for want := 0; want < n; {
+		k, err := fio.BlockingRead(buf)
+		if err != nil {
+			t.Fatalf("BlockingRead() error = %v", err)
+		}
+		got = append(got, buf[:k]...)
+		want = strings.Count(string(got), "\n")
+	}
+	lines := strings.Split(strings.TrimSuffix(string(got), "\n"), "\n")
+	for i, line := range lines {
+		if line != strconv.Itoa(i) {
+			t.Fatalf("line %d = %q, want %q", i, line, strconv.Itoa(i))
+		}
+	}
+}
+
+func TestSyncFakeIO_SetReadDeadline(t *testing.T) {
+	fio := &SyncFakeIO{}
+	_ = fio.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	start := time.Now()
+	_, err := fio.BlockingRead(make([]byte, 8))
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("BlockingRead() error = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Errorf("BlockingRead() returned before the deadline")
+	}
+
+	// a zero time clears the deadline
+	_ = fio.SetReadDeadline(time.Time{})
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = fio.WriteByte('x')
+	}()
+	buf := make([]byte, 1)
+	if _, err = fio.BlockingRead(buf); err != nil {
+		t.Fatalf("BlockingRead() error = %v", err)
+	}
+	if buf[0] != 'x' {
+		t.Errorf("BlockingRead() = %q, want %q", buf[0], 'x')
+	}
+}
+
+func TestSyncFakeIO_String(t *testing.T) {
+	fio := NewSyncFakeIOString("hello world")
+	str := fio.String()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_, _ = fio.WriteAt([]byte("HELLO"), 0)
+			fio.Reset()
+			_, _ = fio.WriteString("xxxxxxxxxxx")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if str != "hello world" {
+			t.Fatalf("String() = %q, changed by a later write", str)
+		}
+	}
+	<-done
+	if str != "hello world" {
+		t.Errorf("String() = %q, want %q", str, "hello world")
+	}
+}
+
+func TestSyncFakeIO_Snapshot(t *testing.T) {
+	fio := &SyncFakeIO{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 7)
+		for i := 0; i < 2000; i++ {
+			_, _ = fio.WriteString("0123456789")
+			_, _ = fio.Read(buf)
+			if i%100 == 0 {
+				fio.Reset()
+			}
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		n, c, size, off := fio.Snapshot()
+		if int64(n) > size || size > int64(c) || int64(n) != size-off {
+			t.Fatalf("Snapshot() = (%d, %d, %d, %d), inconsistent", n, c, size, off)
+		}
+	}
+}
+
+func TestSyncFakeIO_ReadFromN(t *testing.T) {
+	fio := NewSyncFakeIO(nil)
+	r := strings.NewReader(strings.Repeat("x", 10000))
+	n, err := fio.ReadFromN(r, 1000)
+	if err != nil || n != 1000 || fio.Len() != 1000 {
+		t.Errorf("ReadFromN() = %d, %v, len %d, want 1000, nil, len 1000", n, err, fio.Len())
+	}
+	if r.Len() != 9000 {
+		t.Errorf("ReadFromN() read %d bytes from the reader, want 1000", 10000-r.Len())
+	}
+}
+
+func TestSyncFakeIO_WriteToRemainder(t *testing.T) {
+	for _, w := range []interface {
+		io.Writer
+		io.Reader
+		io.WriterTo
+	}{NewFakeIO(nil), NewSyncFakeIO(nil)} {
+		t.Run(fmt.Sprintf("%T", w), func(t *testing.T) {
+			_, _ = w.Write([]byte("hello "))
+			head := make([]byte, 3)
+			if _, err := io.ReadFull(w, head); err != nil {
+				t.Fatal(err)
+			}
+			_, _ = w.Write([]byte("world"))
+			var out strings.Builder
+			n, err := w.WriteTo(&out)
+			if err != nil || n != 8 || out.String() != "lo world" {
+				t.Errorf("WriteTo() = %d, %v, %q, want 8, nil, %q", n, err, out.String(), "lo world")
+			}
+			if n, _ = w.WriteTo(&out); n != 0 {
+				t.Errorf("second WriteTo() = %d, want 0", n)
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"strconv"
+	"strings"
 	"testing"
 	"unicode/utf8"
 
@@ -623,6 +625,106 @@ func TestBufferGrowth(t *testing.T) {
 	}
 }
 
+func TestWriteAppend(t *testing.T) {
+	var got FakeIO
+	var want []byte
+	for i := 0; i < 1000; i++ {
+		b := got.AvailableBuffer()
+		if len(b) != 0 || cap(b) != got.Available() {
+			t.Fatalf("AvailableBuffer() len = %d, cap = %d; want 0, %d", len(b), cap(b), got.Available())
+		}
+		b = strconv.AppendInt(b, int64(i), 10)
+		want = strconv.AppendInt(want, int64(i), 10)
+		got.Write(b)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Fatalf("Bytes() = %q, want %q", got.Bytes(), want)
+	}
+
+	// With a sufficiently sized buffer, there should be no allocations.
+	n := testing.AllocsPerRun(100, func() {
+		got.Reset()
+		for i := 0; i < 1000; i++ {
+			b := got.AvailableBuffer()
+			b = strconv.AppendInt(b, int64(i), 10)
+			got.Write(b)
+		}
+	})
+	if n > 0 {
+		t.Errorf("allocations occurred while appending")
+	}
+}
+
+func TestAppendf(t *testing.T) {
+	var buf FakeIO
+	buf.WriteString("head ")
+	n, err := buf.Appendf("%s=%d %.2f", "key", 42, 3.14159)
+	if err != nil {
+		t.Fatalf("Appendf() error = %v", err)
+	}
+	if want := "head key=42 3.14"; buf.String() != want || n != len(want)-5 {
+		t.Errorf("Appendf() = %q, %d; want %q, %d", buf.String(), n, want, len(want)-5)
+	}
+
+	// the consumed bytes are recovered the same as Write
+	buf.Next(buf.Len())
+	buf.Appendf("%d", 1)
+	if buf.String() != "1" || buf.Size() != 1 {
+		t.Errorf("Appendf() = %q, size %d; want %q, size 1", buf.String(), buf.Size(), "1")
+	}
+}
+
+func TestFill(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 64, 1000, 4097} {
+		var buf FakeIO
+		buf.WriteString("head")
+		if got, err := buf.Fill('x', n); got != n || err != nil {
+			t.Fatalf("Fill(%d) = %d, %v; want %d, nil", n, got, err, n)
+		}
+		check(t, fmt.Sprintf("Fill(%d)", n), &buf, "head"+strings.Repeat("x", n))
+	}
+}
+
+func BenchmarkFill(b *testing.B) {
+	const n = 4 << 10
+	b.SetBytes(n)
+	buf := NewFakeIO(make([]byte, 0, n))
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		buf.Fill('x', n)
+	}
+}
+
+func BenchmarkFillByWriteByte(b *testing.B) {
+	const n = 4 << 10
+	b.SetBytes(n)
+	buf := NewFakeIO(make([]byte, 0, n))
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		for j := 0; j < n; j++ {
+			buf.WriteByte('x')
+		}
+	}
+}
+
+func BenchmarkAppendf(b *testing.B) {
+	buf := NewFakeIO(make([]byte, 0, 1024))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		buf.Appendf("%s=%d %.2f", "key", i, 3.14159)
+	}
+}
+
+func BenchmarkFprintf(b *testing.B) {
+	buf := NewFakeIO(make([]byte, 0, 1024))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		fmt.Fprintf(buf, "%s=%d %.2f", "key", i, 3.14159)
+	}
+}
+
 func BenchmarkWriteByte(b *testing.B) {
 	const n = 4 << 10
 	b.SetBytes(n)
@@ -0,0 +1,54 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import (
+	"hash"
+	"io"
+)
+
+// A ChecksumWriter writes to the underlying io.Writer (e.g. a FakeIO) and computes
+// a running checksum of the written bytes, avoiding a second pass over the data.
+// Only the bytes accepted by the underlying writer are hashed.
+type ChecksumWriter struct {
+	w io.Writer
+	h hash.Hash
+}
+
+// NewChecksumWriter returns a ChecksumWriter that writes to w and hashes with h,
+// typically a hash.Hash32 (crc32) or hash.Hash64 (crc64, fnv).
+func NewChecksumWriter(w io.Writer, h hash.Hash) *ChecksumWriter {
+	return &ChecksumWriter{w: w, h: h}
+}
+
+// Write implements the io.Writer interface.
+func (cw *ChecksumWriter) Write(p []byte) (n int, err error) {
+	n, err = cw.w.Write(p)
+	if n > 0 {
+		// hash.Hash.Write never returns an error
+		_, _ = cw.h.Write(p[:n])
+	}
+	return
+}
+
+// Sum returns the checksum of the bytes written so far, in the big-endian
+// byte order of hash.Hash.Sum.
+func (cw *ChecksumWriter) Sum() []byte { return cw.h.Sum(nil) }
+
+// Hash returns the underlying hash, e.g. to read a hash.Hash32 Sum32 or to Reset it.
+func (cw *ChecksumWriter) Hash() hash.Hash { return cw.h }
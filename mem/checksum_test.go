@@ -0,0 +1,62 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"bytes"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"hash/fnv"
+	"strings"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestChecksumWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		newHash func() hash.Hash
+	}{
+		{name: "crc32", newHash: func() hash.Hash { return crc32.NewIEEE() }},
+		{name: "crc64", newHash: func() hash.Hash { return crc64.New(crc64.MakeTable(crc64.ECMA)) }},
+		{name: "fnv64a", newHash: func() hash.Hash { return fnv.New64a() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := NewFakeIO(nil)
+			cw := NewChecksumWriter(fio, tt.newHash())
+			for i := 0; i < 100; i++ {
+				_, _ = cw.Write([]byte(strings.Repeat("data", i)))
+			}
+			h := tt.newHash()
+			_, _ = h.Write(fio.Bytes())
+			if got, want := cw.Sum(), h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("Sum() = %x, want %x", got, want)
+			}
+		})
+	}
+
+	cw := NewChecksumWriter(NewFakeIO(nil), crc32.NewIEEE())
+	_, _ = cw.Write([]byte("hello"))
+	if got, want := cw.Hash().(hash.Hash32).Sum32(), crc32.ChecksumIEEE([]byte("hello")); got != want {
+		t.Errorf("Hash().Sum32() = %x, want %x", got, want)
+	}
+
+}
@@ -0,0 +1,81 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// A CountingReader counts the bytes read from the underlying io.Reader.
+type CountingReader struct {
+	r      io.Reader
+	n      atomic.Int64
+	onRead func(n int64)
+}
+
+// NewCountingReader returns a CountingReader that reads from r.
+// If onRead is not nil, it is called after every Read with the number
+// of bytes read by that call.
+func NewCountingReader(r io.Reader, onRead func(n int64)) *CountingReader {
+	return &CountingReader{r: r, onRead: onRead}
+}
+
+// Read implements the io.Reader interface.
+func (cr *CountingReader) Read(p []byte) (n int, err error) {
+	n, err = cr.r.Read(p)
+	if n > 0 {
+		cr.n.Add(int64(n))
+		if cr.onRead != nil {
+			cr.onRead(int64(n))
+		}
+	}
+	return
+}
+
+// Count returns the total number of bytes read, it is safe to call it from other goroutines.
+func (cr *CountingReader) Count() int64 { return cr.n.Load() }
+
+// A CountingWriter counts the bytes written to the underlying io.Writer.
+type CountingWriter struct {
+	w       io.Writer
+	n       atomic.Int64
+	onWrite func(n int64)
+}
+
+// NewCountingWriter returns a CountingWriter that writes to w.
+// If onWrite is not nil, it is called after every Write with the number
+// of bytes written by that call.
+func NewCountingWriter(w io.Writer, onWrite func(n int64)) *CountingWriter {
+	return &CountingWriter{w: w, onWrite: onWrite}
+}
+
+// Write implements the io.Writer interface.
+func (cw *CountingWriter) Write(p []byte) (n int, err error) {
+	n, err = cw.w.Write(p)
+	if n > 0 {
+		cw.n.Add(int64(n))
+		if cw.onWrite != nil {
+			cw.onWrite(int64(n))
+		}
+	}
+	return
+}
+
+// Count returns the total number of bytes written, it is safe to call it from other goroutines.
+func (cw *CountingWriter) Count() int64 { return cw.n.Load() }
@@ -0,0 +1,69 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestCountingReader(t *testing.T) {
+	data := strings.Repeat("0123456789", 1000)
+	var calls, total int64
+	cr := NewCountingReader(strings.NewReader(data), func(n int64) {
+		calls++
+		total += n
+	})
+	fio := &FakeIO{}
+	n, err := io.CopyBuffer(struct{ io.Writer }{fio}, cr, make([]byte, 512))
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	if n != int64(len(data)) || cr.Count() != n || total != n {
+		t.Errorf("Count() = %d, callback total = %d, want %d", cr.Count(), total, n)
+	}
+	if calls < 2 {
+		t.Errorf("onRead called %d times, want per read", calls)
+	}
+	if fio.String() != data {
+		t.Errorf("CountingReader changed the data")
+	}
+}
+
+func TestCountingWriter(t *testing.T) {
+	fio := &FakeIO{}
+	var total int64
+	cw := NewCountingWriter(fio, func(n int64) { total += n })
+	for i := 0; i < 3; i++ {
+		if _, err := io.WriteString(cw, "あいう"); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if want := int64(fio.Len()); cw.Count() != want || total != want {
+		t.Errorf("Count() = %d, callback total = %d, want %d", cw.Count(), total, want)
+	}
+
+	nilCallback := NewCountingWriter(io.Discard, nil)
+	_, _ = nilCallback.Write([]byte("abc"))
+	if nilCallback.Count() != 3 {
+		t.Errorf("Count() = %d, want %d", nilCallback.Count(), 3)
+	}
+}
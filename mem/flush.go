@@ -0,0 +1,77 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import "io"
+
+// A FlushBuffer accumulates the writes in a FakeIO and flushes them to the underlying
+// io.Writer once the buffered length reaches a threshold, like a bufio.Writer with
+// a growable buffer. The buffered data must be flushed at the end with Flush.
+type FlushBuffer struct {
+	fio       FakeIO
+	w         io.Writer
+	threshold int
+}
+
+// NewFlushBuffer returns a FlushBuffer writing to w when threshold bytes are buffered,
+// a threshold <= 0 flushes every write.
+func NewFlushBuffer(w io.Writer, threshold int) *FlushBuffer {
+	return &FlushBuffer{w: w, threshold: threshold}
+}
+
+// Write appends p to the buffer and flushes it if the threshold is reached.
+// p is always buffered entirely, so n is len(p) and err is the error of the flush;
+// the bytes not flushed stay buffered.
+func (fb *FlushBuffer) Write(p []byte) (n int, err error) {
+	n, _ = fb.fio.Write(p)
+	return n, fb.flushIfFull()
+}
+
+// WriteString is like Write but appends the contents of s.
+func (fb *FlushBuffer) WriteString(s string) (n int, err error) {
+	n, _ = fb.fio.WriteString(s)
+	return n, fb.flushIfFull()
+}
+
+// WriteByte is like Write but appends the byte c.
+func (fb *FlushBuffer) WriteByte(c byte) error {
+	_ = fb.fio.WriteByte(c)
+	return fb.flushIfFull()
+}
+
+// WriteRune is like Write but appends the UTF-8 encoding of r.
+func (fb *FlushBuffer) WriteRune(r rune) (n int, err error) {
+	n, _ = fb.fio.WriteRune(r)
+	return n, fb.flushIfFull()
+}
+
+// Flush writes the buffered data to the underlying io.Writer.
+func (fb *FlushBuffer) Flush() error {
+	_, err := fb.fio.WriteTo(fb.w)
+	return err
+}
+
+// Len returns the number of bytes buffered and not flushed yet.
+func (fb *FlushBuffer) Len() int { return fb.fio.Len() }
+
+func (fb *FlushBuffer) flushIfFull() error {
+	if fb.fio.Len() >= fb.threshold {
+		return fb.Flush()
+	}
+	return nil
+}
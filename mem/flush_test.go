@@ -0,0 +1,74 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+// recordWriter records the length of each Write.
+type recordWriter struct {
+	bytes.Buffer
+	writes []int
+	err    error
+}
+
+func (rw *recordWriter) Write(p []byte) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	rw.writes = append(rw.writes, len(p))
+	return rw.Buffer.Write(p)
+}
+
+func TestFlushBuffer(t *testing.T) {
+	w := &recordWriter{}
+	fb := NewFlushBuffer(w, 10)
+	_, _ = fb.WriteString("abcd")
+	_ = fb.WriteByte('e')
+	_, _ = fb.WriteRune('日')
+	if len(w.writes) != 0 || fb.Len() != 8 {
+		t.Fatalf("flushed before the threshold, writes = %v, len = %d", w.writes, fb.Len())
+	}
+	_, _ = fb.Write([]byte("fg"))
+	if len(w.writes) != 1 || w.writes[0] != 10 || fb.Len() != 0 {
+		t.Fatalf("no flush at the threshold, writes = %v, len = %d", w.writes, fb.Len())
+	}
+	_, _ = fb.Write([]byte(strings.Repeat("x", 25)))
+	if len(w.writes) != 2 || w.writes[1] != 25 {
+		t.Errorf("no flush of a large write, writes = %v", w.writes)
+	}
+	_, _ = fb.WriteString("tail")
+	if err := fb.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if want := "abcde日fg" + strings.Repeat("x", 25) + "tail"; w.String() != want {
+		t.Errorf("flushed data = %q, want %q", w.String(), want)
+	}
+
+	stop := errors.New("stop")
+	fb = NewFlushBuffer(&recordWriter{err: stop}, 2)
+	if n, err := fb.WriteString("abc"); n != 3 || err != stop || fb.Len() != 3 {
+		t.Errorf("WriteString() = %d, %v, len %d, want 3, %v, len 3", n, err, fb.Len(), stop)
+	}
+}
@@ -0,0 +1,120 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrFrozen is returned by the write methods of a FrozenFakeIO.
+var ErrFrozen = errors.New("bytes.FakeIO: write to a frozen buffer")
+
+// A FrozenFakeIO is a read-only view of the content of a FakeIO, see FakeIO.Freeze.
+// It implements FakeReader, and FakeWriter with write methods returning ErrFrozen.
+type FrozenFakeIO struct {
+	r *FakeIO
+}
+
+var (
+	_ FakeReader = (*FrozenFakeIO)(nil)
+	_ FakeWriter = (*FrozenFakeIO)(nil)
+)
+
+// Freeze returns a read-only view of the unread portion of the buffer with its own read offset,
+// to hand a built payload to consumers which must not modify it. The view shares the bytes
+// of the buffer without a copy, so the buffer must not be modified while the view is in use.
+func (fio *FakeIO) Freeze() *FrozenFakeIO {
+	return &FrozenFakeIO{r: newFrozenReader(fio.Bytes())}
+}
+
+// newFrozenReader returns a FakeIO reading b which never resets itself,
+// so the view keeps its content after it is read to the end.
+func newFrozenReader(b []byte) *FakeIO {
+	r := NewFakeIO(b[:len(b):len(b)])
+	r.ManualReset = true
+	return r
+}
+
+// Len returns the number of bytes of the unread portion of the view.
+func (ff *FrozenFakeIO) Len() int { return ff.r.Len() }
+
+// Size returns the length of the frozen content.
+func (ff *FrozenFakeIO) Size() int64 { return ff.r.Size() }
+
+// Bytes returns the unread portion of the view, the slice must not be modified.
+func (ff *FrozenFakeIO) Bytes() []byte { return ff.r.Bytes() }
+
+// String returns the unread portion of the view as a string.
+func (ff *FrozenFakeIO) String() string { return ff.r.String() }
+
+// Read implements the io.Reader interface.
+func (ff *FrozenFakeIO) Read(b []byte) (n int, err error) { return ff.r.Read(b) }
+
+// ReadAt implements the io.ReaderAt interface.
+func (ff *FrozenFakeIO) ReadAt(b []byte, off int64) (n int, err error) { return ff.r.ReadAt(b, off) }
+
+// ReadByte implements the io.ByteReader interface.
+func (ff *FrozenFakeIO) ReadByte() (byte, error) { return ff.r.ReadByte() }
+
+// UnreadByte complements ReadByte in implementing the io.ByteScanner interface.
+func (ff *FrozenFakeIO) UnreadByte() error { return ff.r.UnreadByte() }
+
+// ReadRune implements the io.RuneReader interface.
+func (ff *FrozenFakeIO) ReadRune() (ch rune, size int, err error) { return ff.r.ReadRune() }
+
+// UnreadRune complements ReadRune in implementing the io.RuneScanner interface.
+func (ff *FrozenFakeIO) UnreadRune() error { return ff.r.UnreadRune() }
+
+// Seek implements the io.Seeker interface.
+func (ff *FrozenFakeIO) Seek(offset int64, whence int) (int64, error) {
+	return ff.r.Seek(offset, whence)
+}
+
+// SeekStart moves the read offset to the start of the view.
+func (ff *FrozenFakeIO) SeekStart() { ff.r.SeekStart() }
+
+// SeekEnd moves the read offset to the end of the view.
+func (ff *FrozenFakeIO) SeekEnd() { ff.r.SeekEnd() }
+
+// WriteTo implements the io.WriterTo interface.
+func (ff *FrozenFakeIO) WriteTo(w io.Writer) (n int64, err error) { return ff.r.WriteTo(w) }
+
+// ResetTo resets the view to be reading from b, b must not be modified either.
+func (ff *FrozenFakeIO) ResetTo(b []byte) { ff.r = newFrozenReader(b) }
+
+// Close implements the io.Closer interface, the view becomes empty.
+func (ff *FrozenFakeIO) Close() error { return ff.r.Close() }
+
+// Write always returns ErrFrozen.
+func (ff *FrozenFakeIO) Write([]byte) (n int, err error) { return 0, ErrFrozen }
+
+// WriteAt always returns ErrFrozen.
+func (ff *FrozenFakeIO) WriteAt([]byte, int64) (n int, err error) { return 0, ErrFrozen }
+
+// WriteRune always returns ErrFrozen.
+func (ff *FrozenFakeIO) WriteRune(rune) (n int, err error) { return 0, ErrFrozen }
+
+// WriteString always returns ErrFrozen.
+func (ff *FrozenFakeIO) WriteString(string) (n int, err error) { return 0, ErrFrozen }
+
+// WriteByte always returns ErrFrozen.
+func (ff *FrozenFakeIO) WriteByte(byte) error { return ErrFrozen }
+
+// ReadFrom always returns ErrFrozen without reading r.
+func (ff *FrozenFakeIO) ReadFrom(io.Reader) (n int64, err error) { return 0, ErrFrozen }
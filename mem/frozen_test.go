@@ -0,0 +1,90 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestFakeIO_Freeze(t *testing.T) {
+	fio := NewFakeIO(make([]byte, 0, 64))
+	_, _ = fio.WriteString("header;payload")
+	_ = fio.Next(7)
+	frozen := fio.Freeze()
+
+	writes := []struct {
+		name  string
+		write func() error
+	}{
+		{name: "Write", write: func() error { _, err := frozen.Write([]byte("x")); return err }},
+		{name: "WriteAt", write: func() error { _, err := frozen.WriteAt([]byte("x"), 0); return err }},
+		{name: "WriteRune", write: func() error { _, err := frozen.WriteRune('x'); return err }},
+		{name: "WriteString", write: func() error { _, err := frozen.WriteString("x"); return err }},
+		{name: "WriteByte", write: func() error { return frozen.WriteByte('x') }},
+		{name: "ReadFrom", write: func() error { _, err := frozen.ReadFrom(strings.NewReader("x")); return err }},
+	}
+	for _, tt := range writes {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.write(); err != ErrFrozen {
+				t.Errorf("%s() error = %v, want %v", tt.name, err, ErrFrozen)
+			}
+		})
+	}
+
+	var r FakeReader = frozen
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "payload" {
+		t.Errorf("ReadAll() = %q, %v, want %q", got, err, "payload")
+	}
+	r.SeekStart()
+	if b, _ := r.ReadByte(); b != 'p' {
+		t.Errorf("ReadByte() after SeekStart = %q, want %q", b, 'p')
+	}
+	if fio.String() != "payload" {
+		t.Errorf("Freeze() view reads advanced the buffer = %q", fio.String())
+	}
+
+	// draining the view doesn't reset its content
+	for _, drain := range []func(){
+		func() { _, _ = frozen.WriteTo(io.Discard) },
+		func() {
+			for _, err := frozen.ReadByte(); err == nil; _, err = frozen.ReadByte() {
+			}
+		},
+		func() {
+			for _, _, err := frozen.ReadRune(); err == nil; _, _, err = frozen.ReadRune() {
+			}
+		},
+	} {
+		frozen.SeekStart()
+		drain()
+		frozen.SeekStart()
+		if frozen.Size() != 7 || frozen.String() != "payload" {
+			t.Errorf("SeekStart() after drain = %q, size %d, want %q, 7", frozen.String(), frozen.Size(), "payload")
+		}
+	}
+
+	// the view shares the bytes without copying
+	if &frozen.Bytes()[0] != &fio.Bytes()[0] {
+		t.Errorf("Freeze() copied the buffer")
+	}
+}
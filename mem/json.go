@@ -0,0 +1,172 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import (
+	"unicode/utf8"
+
+	"github.com/pashifika/util/conv"
+)
+
+// A JSONBuilder writes a JSON document token by token into a FakeIO,
+// so large arrays can be built incrementally without holding the whole structure.
+// The comma separators are written automatically and the strings are escaped.
+// It panics if the End methods do not match the opened container.
+type JSONBuilder struct {
+	fio      *FakeIO
+	stack    []jsonScope
+	afterKey bool
+}
+
+type jsonScope struct {
+	closer byte // ']' or '}'
+	count  int  // number of elements written in the container
+}
+
+// NewJSONBuilder returns a JSONBuilder appending to fio.
+func NewJSONBuilder(fio *FakeIO) *JSONBuilder { return &JSONBuilder{fio: fio} }
+
+// BeginArray writes the start of an array value.
+func (jb *JSONBuilder) BeginArray() *JSONBuilder { return jb.begin('[', ']') }
+
+// EndArray writes the end of the current array.
+func (jb *JSONBuilder) EndArray() *JSONBuilder { return jb.end(']') }
+
+// BeginObject writes the start of an object value.
+func (jb *JSONBuilder) BeginObject() *JSONBuilder { return jb.begin('{', '}') }
+
+// EndObject writes the end of the current object.
+func (jb *JSONBuilder) EndObject() *JSONBuilder { return jb.end('}') }
+
+// Key writes the key of the next object member, it must be followed by a value.
+func (jb *JSONBuilder) Key(key string) *JSONBuilder {
+	if len(jb.stack) == 0 || jb.stack[len(jb.stack)-1].closer != '}' || jb.afterKey {
+		panic("mem.JSONBuilder: key outside of an object")
+	}
+	jb.separator()
+	b := appendJSONString(jb.fio.AvailableBuffer(), key)
+	_, _ = jb.fio.Write(append(b, ':'))
+	jb.afterKey = true
+	return jb
+}
+
+// StringValue writes an escaped string value.
+func (jb *JSONBuilder) StringValue(v string) *JSONBuilder {
+	jb.value()
+	_, _ = jb.fio.Write(appendJSONString(jb.fio.AvailableBuffer(), v))
+	return jb
+}
+
+// IntValue writes an integer value.
+func (jb *JSONBuilder) IntValue(v int64) *JSONBuilder {
+	jb.value()
+	_, _ = jb.fio.Write(conv.AppendInt(jb.fio.AvailableBuffer(), v))
+	return jb
+}
+
+func (jb *JSONBuilder) begin(opener, closer byte) *JSONBuilder {
+	jb.value()
+	_ = jb.fio.WriteByte(opener)
+	jb.stack = append(jb.stack, jsonScope{closer: closer})
+	return jb
+}
+
+func (jb *JSONBuilder) end(closer byte) *JSONBuilder {
+	if len(jb.stack) == 0 || jb.stack[len(jb.stack)-1].closer != closer || jb.afterKey {
+		panic("mem.JSONBuilder: unbalanced " + string(closer))
+	}
+	jb.stack = jb.stack[:len(jb.stack)-1]
+	_ = jb.fio.WriteByte(closer)
+	return jb
+}
+
+// value prepares the writing of a value, the comma is written for an array element.
+func (jb *JSONBuilder) value() {
+	if jb.afterKey {
+		jb.afterKey = false
+		return
+	}
+	if len(jb.stack) == 0 {
+		return
+	}
+	if jb.stack[len(jb.stack)-1].closer == '}' {
+		panic("mem.JSONBuilder: object value without a key")
+	}
+	jb.separator()
+}
+
+// separator writes the comma before a new element of the current container.
+func (jb *JSONBuilder) separator() {
+	scope := &jb.stack[len(jb.stack)-1]
+	if scope.count > 0 {
+		_ = jb.fio.WriteByte(',')
+	}
+	scope.count++
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as a quoted JSON string to dst, like encoding/json
+// the invalid UTF-8 is replaced by U+FFFD and U+2028, U+2029 are escaped.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
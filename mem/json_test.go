@@ -0,0 +1,88 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestJSONBuilder(t *testing.T) {
+	fio := NewFakeIO(nil)
+	jb := NewJSONBuilder(fio)
+	jb.BeginObject().
+		Key("name").StringValue("say \"hi\"\n\t\\ \x01 日本 \u2028").
+		Key("ids").BeginArray().IntValue(1).IntValue(-2).IntValue(math.MaxInt64).EndArray().
+		Key("empty").BeginArray().EndArray().
+		Key("items").BeginArray()
+	for i := 0; i < 3; i++ {
+		jb.BeginObject().Key("id").IntValue(int64(i)).Key("tags").BeginArray().StringValue("a").StringValue("b").EndArray().EndObject()
+	}
+	jb.EndArray().
+		Key("nested").BeginObject().EndObject().
+		EndObject()
+
+	want := `{"name":"say \"hi\"\n\t\\ \u0001 日本 \u2028","ids":[1,-2,9223372036854775807],"empty":[],` +
+		`"items":[{"id":0,"tags":["a","b"]},{"id":1,"tags":["a","b"]},{"id":2,"tags":["a","b"]}],"nested":{}}`
+	if got := fio.String(); got != want {
+		t.Errorf("JSONBuilder = %s\nwant %s", got, want)
+	}
+	if !json.Valid(fio.Bytes()) {
+		t.Fatalf("JSONBuilder produced invalid JSON: %s", fio.String())
+	}
+	var doc struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(fio.Bytes(), &doc); err != nil || doc.Name != "say \"hi\"\n\t\\ \x01 日本 \u2028" {
+		t.Errorf("json.Unmarshal() = %q, %v", doc.Name, err)
+	}
+}
+
+func TestJSONBuilder_InvalidUTF8(t *testing.T) {
+	fio := NewFakeIO(nil)
+	NewJSONBuilder(fio).StringValue("a\xffb")
+	if got, want := fio.String(), `"a\ufffdb"`; got != want {
+		t.Errorf("StringValue() = %s, want %s", got, want)
+	}
+}
+
+func TestJSONBuilder_Unbalanced(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(jb *JSONBuilder)
+	}{
+		{name: "end without begin", build: func(jb *JSONBuilder) { jb.EndArray() }},
+		{name: "mismatched end", build: func(jb *JSONBuilder) { jb.BeginArray().EndObject() }},
+		{name: "key in array", build: func(jb *JSONBuilder) { jb.BeginArray().Key("k") }},
+		{name: "value without key", build: func(jb *JSONBuilder) { jb.BeginObject().IntValue(1) }},
+		{name: "end after key", build: func(jb *JSONBuilder) { jb.BeginObject().Key("k").EndObject() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("JSONBuilder did not panic")
+				}
+			}()
+			tt.build(NewJSONBuilder(NewFakeIO(nil)))
+		})
+	}
+}
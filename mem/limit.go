@@ -0,0 +1,57 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrLimitExceeded is returned by the CapReader when the source has more data than the limit.
+var ErrLimitExceeded = errors.New("mem.CapReader: read limit exceeded")
+
+// CapReader returns a Reader that reads from r but stops with ErrLimitExceeded
+// when r has more than limit bytes, unlike io.LimitReader which returns io.EOF.
+// If r has no more than limit bytes, the errors of r (usually io.EOF) are returned as is.
+func CapReader(r io.Reader, limit int64) io.Reader { return &capReader{r: r, n: limit} }
+
+type capReader struct {
+	r io.Reader
+	n int64 // max bytes remaining
+}
+
+func (c *capReader) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if c.n <= 0 {
+		// probe one byte to tell the real EOF apart from the limit
+		var b [1]byte
+		n, err = c.r.Read(b[:])
+		if n > 0 {
+			return 0, ErrLimitExceeded
+		}
+		return 0, err
+	}
+	if int64(len(p)) > c.n {
+		p = p[0:c.n]
+	}
+	n, err = c.r.Read(p)
+	c.n -= int64(n)
+	return
+}
@@ -0,0 +1,52 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestCapReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		limit   int64
+		want    string
+		wantErr error
+	}{
+		{name: "under limit", data: "12345", limit: 10, want: "12345", wantErr: nil},
+		{name: "equal limit", data: "1234567890", limit: 10, want: "1234567890", wantErr: nil},
+		{name: "over limit", data: "12345678901", limit: 10, want: "1234567890", wantErr: ErrLimitExceeded},
+		{name: "zero limit", data: "1", limit: 0, want: "", wantErr: ErrLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fio := &FakeIO{}
+			_, err := fio.ReadFrom(CapReader(strings.NewReader(tt.data), tt.limit))
+			if err != tt.wantErr {
+				t.Errorf("ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fio.String() != tt.want {
+				t.Errorf("ReadFrom() = %q, want %q", fio.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import (
+	"io"
+	"sync"
+)
+
+// A FakeIOPool is a pool of FakeIO buffers to reuse their backing arrays,
+// e.g. one buffer per request in a pipeline. The zero value is ready to use.
+// A FakeIOPool must not be copied after first use.
+type FakeIOPool struct {
+	pool sync.Pool
+
+	// MaxSize is the maximal capacity of a buffer kept by Put, the larger ones are dropped
+	// so that a single huge request does not pin its memory. Zero means no limit.
+	MaxSize int
+}
+
+// Get returns an empty buffer with a capacity of at least minSize,
+// the backing array of a buffer previously given to Put is reused when it is large enough.
+func (p *FakeIOPool) Get(minSize int64) *FakeIO {
+	if fio, ok := p.pool.Get().(*FakeIO); ok {
+		if int64(fio.Cap()) >= minSize {
+			return fio
+		}
+		p.pool.Put(fio)
+	}
+	return NewFakeIO(make([]byte, 0, minSize))
+}
+
+// Put resets fio as a new buffer and returns it to the pool,
+// fio and the slices returned by its methods must not be used after the call.
+func (p *FakeIOPool) Put(fio *FakeIO) {
+	if fio == nil || (p.MaxSize > 0 && fio.Cap() > p.MaxSize) {
+		return
+	}
+	*fio = FakeIO{buf: fio.buf[:0]}
+	p.pool.Put(fio)
+}
+
+// ReadCloser is like FakeIO.ReadCloser but Close returns fio to the pool,
+// after the running Read if any, so the buffer is never reused in the middle of a Read.
+func (p *FakeIOPool) ReadCloser(fio *FakeIO) io.ReadCloser {
+	return &readCloser{fio: fio, close: p.Put}
+}
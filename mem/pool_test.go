@@ -0,0 +1,100 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestFakeIOPool(t *testing.T) {
+	var pool FakeIOPool
+	fio := pool.Get(1024)
+	if fio.Len() != 0 || fio.Cap() < 1024 {
+		t.Fatalf("Get() len = %d, cap = %d, want 0, >= 1024", fio.Len(), fio.Cap())
+	}
+
+	// sync.Pool may drop an item (always under the race detector), so retry a few times
+	reused := false
+	for i := 0; i < 10 && !reused; i++ {
+		backing := &fio.AvailableBuffer()[:1][0]
+		_, _ = fio.WriteString("hello")
+		fio.ManualReset = true
+		pool.Put(fio)
+		fio = pool.Get(512)
+		if fio.Len() != 0 || fio.ManualReset {
+			t.Fatalf("Get() returned a buffer not reset, len = %d, ManualReset = %v", fio.Len(), fio.ManualReset)
+		}
+		reused = &fio.AvailableBuffer()[:1][0] == backing
+	}
+	if !reused {
+		t.Errorf("Get() after Put() did not reuse the backing array")
+	}
+
+	if fio = pool.Get(4096); fio.Cap() < 4096 {
+		t.Errorf("Get() cap = %d, want >= 4096", fio.Cap())
+	}
+}
+
+func TestFakeIOPool_ReadCloser(t *testing.T) {
+	var pool FakeIOPool
+	fio := pool.Get(64)
+	_, _ = fio.WriteString("body")
+	rc := pool.ReadCloser(fio)
+	got, err := io.ReadAll(rc)
+	if err != nil || string(got) != "body" {
+		t.Errorf("ReadCloser() read = %q, %v, want %q", got, err, "body")
+	}
+	_ = rc.Close()
+	if fio.Len() != 0 || fio.Cap() < 64 {
+		t.Errorf("Close() did not reset the buffer for the pool, len = %d, cap = %d", fio.Len(), fio.Cap())
+	}
+}
+
+func TestFakeIOPool_ReadCloserConcurrentClose(t *testing.T) {
+	var pool FakeIOPool
+	for i := 0; i < 100; i++ {
+		fio := pool.Get(1024)
+		_, _ = fio.Write(bytes.Repeat([]byte("x"), 1024))
+		rc := pool.ReadCloser(fio)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			p := make([]byte, 16)
+			for {
+				n, err := rc.Read(p)
+				if n > 0 && !bytes.Equal(p[:n], bytes.Repeat([]byte("x"), n)) {
+					t.Errorf("Read() = %q, want only x", p[:n])
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+		_ = rc.Close()
+		// the buffer may be reused by another request while the transport is still reading
+		reused := pool.Get(1024)
+		_, _ = reused.Write(bytes.Repeat([]byte("y"), 1024))
+		<-done
+		pool.Put(reused)
+	}
+}
@@ -0,0 +1,66 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem
+
+import (
+	"errors"
+	"io"
+)
+
+// A SealedReaderAt is an immutable copy of the content of a buffer serving ReadAt,
+// it is safe for concurrent use without lock as nothing can modify its bytes.
+type SealedReaderAt struct {
+	b []byte
+}
+
+// Seal returns a SealedReaderAt holding a copy of the whole content of the buffer
+// (the same bytes as ReadAt, read or not), later writes to the buffer don't affect it.
+func (fio *FakeIO) Seal() *SealedReaderAt { return newSealedReaderAt(fio.buf) }
+
+// Seal is like FakeIO.Seal, the content is copied under the lock.
+func (fio *SyncFakeIO) Seal() *SealedReaderAt {
+	fio.m.RLock()
+	defer fio.m.RUnlock()
+	return newSealedReaderAt(fio.buf)
+}
+
+func newSealedReaderAt(b []byte) *SealedReaderAt {
+	return &SealedReaderAt{b: append([]byte(nil), b...)}
+}
+
+// ReadAt implements the io.ReaderAt interface, it returns io.EOF when fewer than
+// len(p) bytes are read because the end is reached, including an offset at or past Size.
+func (sr *SealedReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("mem.SealedReaderAt.ReadAt: negative offset")
+	}
+	if off >= int64(len(sr.b)) {
+		return 0, io.EOF
+	}
+	n = copy(p, sr.b[off:])
+	if n < len(p) {
+		err = io.EOF
+	}
+	return
+}
+
+// Size returns the length of the sealed content.
+func (sr *SealedReaderAt) Size() int64 { return int64(len(sr.b)) }
+
+// NewReader returns an io.SectionReader reading the whole sealed content with its own offset.
+func (sr *SealedReaderAt) NewReader() *io.SectionReader { return io.NewSectionReader(sr, 0, sr.Size()) }
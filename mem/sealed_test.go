@@ -0,0 +1,99 @@
+// Package mem
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mem_test
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+
+	. "github.com/pashifika/util/mem"
+)
+
+func TestSealedReaderAt(t *testing.T) {
+	fio := NewFakeIOString("0123456789")
+	_ = fio.Next(3)
+	sr := fio.Seal()
+	_, _ = fio.WriteAt([]byte("xx"), 0)
+	_, _ = fio.WriteString("abc")
+
+	tests := []struct {
+		name    string
+		off     int64
+		size    int
+		want    string
+		wantErr error
+	}{
+		{name: "start", off: 0, size: 4, want: "0123"},
+		{name: "end", off: 6, size: 4, want: "6789"},
+		{name: "short", off: 8, size: 4, want: "89", wantErr: io.EOF},
+		{name: "at size", off: 10, size: 4, want: "", wantErr: io.EOF},
+		{name: "past size", off: 11, size: 4, want: "", wantErr: io.EOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := make([]byte, tt.size)
+			n, err := sr.ReadAt(p, tt.off)
+			if err != tt.wantErr || string(p[:n]) != tt.want {
+				t.Errorf("ReadAt() = %q, %v, want %q, %v", p[:n], err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	if _, err := sr.ReadAt(make([]byte, 1), -1); err == nil {
+		t.Errorf("ReadAt() negative offset error = nil")
+	}
+	if got, _ := io.ReadAll(sr.NewReader()); string(got) != "0123456789" || sr.Size() != 10 {
+		t.Errorf("NewReader() = %q, size %d, want %q, 10", got, sr.Size(), "0123456789")
+	}
+}
+
+func TestSealedReaderAt_Concurrent(t *testing.T) {
+	fio := NewSyncFakeIO(nil)
+	for i := 0; i < 1000; i++ {
+		_, _ = fio.WriteString(strconv.Itoa(i % 10))
+	}
+	sr := fio.Seal()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_, _ = fio.WriteAt([]byte("x"), int64(i))
+		}
+	}()
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			p := make([]byte, 10)
+			for off := int64(g); off < 990; off += 10 {
+				if _, err := sr.ReadAt(p, off); err != nil {
+					t.Errorf("ReadAt() error = %v", err)
+					return
+				}
+				if want := byte('0' + off%10); p[0] != want {
+					t.Errorf("ReadAt() = %q at %d, want %q", p[0], off, want)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -20,13 +20,21 @@ package nets
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/pashifika/util/files"
+	"github.com/pashifika/util/mem"
 )
 
 var (
@@ -37,11 +45,13 @@ var (
 	bigSize = int64(1024 * 1024 * 10)
 
 	// HTTP headers
-	//acceptRangeHeader   = "Accept-Ranges"
+	acceptRangeHeader   = "Accept-Ranges"
 	contentLengthHeader = "Content-Length"
 )
 
-func IsUrl(URL string) (*url.URL, error) {
+// IsUrl parses URL as an absolute request URI with a host. If schemes are given,
+// the (lower case) scheme of URL must be one of them.
+func IsUrl(URL string, schemes ...string) (*url.URL, error) {
 	u, err := url.ParseRequestURI(URL)
 	if err != nil {
 		return nil, err
@@ -49,13 +59,99 @@ func IsUrl(URL string) (*url.URL, error) {
 	if u.Scheme == "" || u.Host == "" {
 		return nil, errors.New("invalid URI for request, url:" + URL)
 	}
+	if len(schemes) != 0 && !hasScheme(u.Scheme, schemes) {
+		return nil, errors.New("unsupported URI scheme for request, url:" + URL)
+	}
 	return u, nil
 }
 
+// IsHttpUrl is like IsUrl but only accepts the http and https schemes.
+func IsHttpUrl(URL string) (*url.URL, error) {
+	return IsUrl(URL, "http", "https")
+}
+
+func hasScheme(scheme string, schemes []string) bool {
+	for _, s := range schemes {
+		if strings.EqualFold(scheme, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildURL appends the path segments to the path of the absolute URL base and adds the query
+// parameters to its query. Each segment is escaped (a "/" in a segment is escaped too) and the
+// empty segments are skipped, so no double slash is produced. The query is encoded by url.Values,
+// sorted by key, and a parameter already in base is replaced.
+func BuildURL(base string, segments []string, query map[string]string) (string, error) {
+	u, err := IsUrl(base)
+	if err != nil {
+		return "", err
+	}
+
+	if len(segments) != 0 {
+		raw := strings.TrimSuffix(u.EscapedPath(), "/")
+		for _, seg := range segments {
+			if seg != "" {
+				raw += "/" + url.PathEscape(seg)
+			}
+		}
+		if u.Path, err = url.PathUnescape(raw); err != nil {
+			return "", err
+		}
+		u.RawPath = raw
+	}
+	if len(query) != 0 {
+		values := u.Query()
+		for k, v := range query {
+			values.Set(k, v)
+		}
+		u.RawQuery = values.Encode()
+	}
+	return u.String(), nil
+}
+
+// RemoteFileInfo issues a HEAD request to the http(s) URL and returns the size of the remote file
+// from the Content-Length header (-1 if the header is missing) and whether the server accepts
+// byte range requests (Accept-Ranges: bytes). A non-2xx status is returned as an error.
+func RemoteFileInfo(URL string) (size int64, acceptRanges bool, err error) {
+	u, err := IsHttpUrl(URL)
+	if err != nil {
+		return -1, false, err
+	}
+	req, err := http.NewRequest("HEAD", u.String(), nil)
+	if err != nil {
+		return -1, false, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, false, err
+	}
+	//noinspection ALL
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return -1, false, fmt.Errorf("head failed, status:%s url:%s", resp.Status, URL)
+	}
+
+	size = -1
+	if clen := resp.Header.Get(contentLengthHeader); clen != "" {
+		if size, err = strconv.ParseInt(clen, 10, 64); err != nil {
+			return -1, false, err
+		}
+	}
+	for _, v := range strings.Split(resp.Header.Get(acceptRangeHeader), ",") {
+		if strings.EqualFold(strings.TrimSpace(v), "bytes") {
+			acceptRanges = true
+		}
+	}
+	return size, acceptRanges, nil
+}
+
 // HttpDownload is auto join the urlPaths to URL parameter
 //goland:noinspection GoUnusedExportedFunction
 func HttpDownload(URL, localPath string, urlPaths ...string) error {
-	u, err := IsUrl(URL)
+	u, err := IsHttpUrl(URL)
 	if err != nil {
 		return err
 	}
@@ -78,27 +174,108 @@ func HttpDownload(URL, localPath string, urlPaths ...string) error {
 	//noinspection ALL
 	defer resp.Body.Close()
 
-	// get download size
-	clen := resp.Header.Get(contentLengthHeader)
-	if clen == "" {
-		clen = "1"
-	}
-
-	size, err := strconv.ParseInt(clen, 10, 64)
-	if err != nil {
-		return err
+	// get download size, -1 is unknown
+	size := int64(-1)
+	if clen := resp.Header.Get(contentLengthHeader); clen != "" {
+		size, err = strconv.ParseInt(clen, 10, 64)
+		if err != nil {
+			return err
+		}
 	}
 
 	if size >= bigSize {
-		err = files.BufferToFile(localPath, resp.Body)
+		cr := mem.NewCountingReader(resp.Body, nil)
+		err = files.BufferToFile(localPath, cr)
+		if err == nil && cr.Count() != size {
+			err = sizeMismatchError(URL, size, cr.Count())
+		}
+		if err != nil {
+			// don't leave a truncated file
+			_ = os.Remove(localPath)
+		}
 	} else {
 		var buf []byte
 		buf, err = ioutil.ReadAll(resp.Body)
 		if err != nil {
 			return err
 		}
+		if size >= 0 && int64(len(buf)) != size {
+			return sizeMismatchError(URL, size, int64(len(buf)))
+		}
 		err = files.ByteToFile(localPath, buf)
 	}
 
 	return err
 }
+
+func sizeMismatchError(URL string, want, got int64) error {
+	return fmt.Errorf("download size mismatch, Content-Length:%d received:%d url:%s", want, got, URL)
+}
+
+// HttpUpload post the filePath file to URL as the fieldName part of a multipart/form-data request,
+// the extraFields are written as form fields before the file (sorted by name).
+// The file is streamed to the request body and is not buffered in memory.
+//
+// If the response status is not 2xx, the response body is closed and an error is returned,
+// otherwise the caller must close the response body.
+//
+//goland:noinspection GoUnusedExportedFunction
+func HttpUpload(URL, fieldName, filePath string, extraFields map[string]string) (*http.Response, error) {
+	u, err := IsHttpUrl(URL)
+	if err != nil {
+		return nil, err
+	}
+	f, err := files.FileOpen(filePath, "r")
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		//noinspection ALL
+		defer f.Close()
+		pw.CloseWithError(writeMultipart(mw, fieldName, f, extraFields))
+	}()
+
+	req, err := http.NewRequest("POST", u.String(), pr)
+	if err != nil {
+		_ = pr.CloseWithError(err)
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		_ = pr.CloseWithError(err)
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		//noinspection ALL
+		resp.Body.Close()
+		return nil, fmt.Errorf("upload failed, status:%s url:%s", resp.Status, URL)
+	}
+	return resp, nil
+}
+
+func writeMultipart(mw *multipart.Writer, fieldName string, f *os.File, extraFields map[string]string) error {
+	keys := make([]string, 0, len(extraFields))
+	for k := range extraFields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := mw.WriteField(k, extraFields[k]); err != nil {
+			return err
+		}
+	}
+
+	part, err := mw.CreateFormFile(fieldName, filepath.Base(f.Name()))
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(part, f); err != nil {
+		return err
+	}
+	return mw.Close()
+}
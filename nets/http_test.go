@@ -0,0 +1,306 @@
+// Package nets
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nets
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestIsUrl(t *testing.T) {
+	type args struct {
+		URL     string
+		schemes []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{name: "any scheme", args: args{URL: "ftp://example.com/a.txt"}, wantErr: false},
+		{name: "no host", args: args{URL: "file:///etc/passwd"}, wantErr: true},
+		{name: "allowed", args: args{URL: "ftp://example.com/a.txt", schemes: []string{"ftp"}}, wantErr: false},
+		{name: "upper case", args: args{URL: "HTTPS://example.com/", schemes: []string{"https"}}, wantErr: false},
+		{name: "not allowed", args: args{URL: "ftp://example.com/a.txt", schemes: []string{"http", "https"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := IsUrl(tt.args.URL, tt.args.schemes...); (err != nil) != tt.wantErr {
+				t.Errorf("IsUrl() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsHttpUrl(t *testing.T) {
+	tests := []struct {
+		name    string
+		URL     string
+		wantErr bool
+	}{
+		{name: "http", URL: "http://example.com/a.txt", wantErr: false},
+		{name: "https", URL: "https://example.com:8443/a.txt?b=c", wantErr: false},
+		{name: "javascript", URL: "javascript://example.com/%0Aalert(1)", wantErr: true},
+		{name: "file", URL: "file://localhost/etc/passwd", wantErr: true},
+		{name: "ftp", URL: "ftp://example.com/a.txt", wantErr: true},
+		{name: "relative", URL: "/a.txt", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := IsHttpUrl(tt.URL); (err != nil) != tt.wantErr {
+				t.Errorf("IsHttpUrl() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildURL(t *testing.T) {
+	type args struct {
+		base     string
+		segments []string
+		query    map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "segments",
+			args: args{base: "https://example.com/api/", segments: []string{"v1", "users"}},
+			want: "https://example.com/api/v1/users",
+		},
+		{
+			name: "spaces and reserved characters",
+			args: args{base: "https://example.com", segments: []string{"my file.txt", "a/b", "c?d#e", "100%"}},
+			want: "https://example.com/my%20file.txt/a%2Fb/c%3Fd%23e/100%25",
+		},
+		{
+			name: "empty segments",
+			args: args{base: "https://example.com/a", segments: []string{"", "b", ""}},
+			want: "https://example.com/a/b",
+		},
+		{
+			name: "escaped base path",
+			args: args{base: "https://example.com/a%2Fb", segments: []string{"c"}},
+			want: "https://example.com/a%2Fb/c",
+		},
+		{
+			name: "query",
+			args: args{base: "https://example.com/search?page=1&q=old", segments: nil,
+				query: map[string]string{"q": "go lang&more", "lang": "ja"}},
+			want: "https://example.com/search?lang=ja&page=1&q=go+lang%26more",
+		},
+		{
+			name:    "relative base",
+			args:    args{base: "/api", segments: []string{"v1"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildURL(tt.args.base, tt.args.segments, tt.args.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BuildURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHttpUpload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		f, fh, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		//noinspection ALL
+		defer f.Close()
+		n, _ := io.Copy(io.Discard, f)
+		_, _ = io.WriteString(w, fh.Filename+":"+strconv.FormatInt(n, 10)+":"+r.FormValue("name"))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "upload.txt")
+	if err := os.WriteFile(path, []byte(strings.Repeat("a", 100000)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := HttpUpload(ts.URL, "file", path, map[string]string{"name": "test"})
+	if err != nil {
+		t.Fatalf("HttpUpload() error = %v", err)
+	}
+	//noinspection ALL
+	defer resp.Body.Close()
+	got, _ := io.ReadAll(resp.Body)
+	if want := "upload.txt:100000:test"; string(got) != want {
+		t.Errorf("HttpUpload() response = %q, want %q", got, want)
+	}
+
+	if _, err = HttpUpload(ts.URL, "other", path, nil); err == nil {
+		t.Errorf("HttpUpload() error = nil, want bad status")
+	}
+	if _, err = HttpUpload(ts.URL, "file", filepath.Join(t.TempDir(), "none"), nil); err == nil {
+		t.Errorf("HttpUpload() error = nil, want file not found")
+	}
+}
+
+func TestRemoteFileInfo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "HEAD" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/ranges":
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", "12345")
+		case "/no-ranges":
+			w.Header().Set("Accept-Ranges", "none")
+			w.Header().Set("Content-Length", "10")
+		case "/chunked":
+			w.Header().Set("Transfer-Encoding", "chunked")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantSize   int64
+		wantRanges bool
+		wantErr    bool
+	}{
+		{name: "accept ranges", path: "/ranges", wantSize: 12345, wantRanges: true},
+		{name: "no ranges", path: "/no-ranges", wantSize: 10, wantRanges: false},
+		{name: "unknown size", path: "/chunked", wantSize: -1, wantRanges: false},
+		{name: "not found", path: "/missing", wantSize: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, ranges, err := RemoteFileInfo(ts.URL + tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RemoteFileInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if size != tt.wantSize || ranges != tt.wantRanges {
+				t.Errorf("RemoteFileInfo() = %d, %v, want %d, %v", size, ranges, tt.wantSize, tt.wantRanges)
+			}
+		})
+	}
+}
+
+func TestHttpDownload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := strings.Repeat("a", 1000)
+		switch r.URL.Path {
+		case "/ok":
+			w.Header().Set("Content-Length", "1000")
+		case "/truncated":
+			// advertise more than sent, the connection is closed after the body
+			w.Header().Set("Content-Length", "2000")
+		case "/chunked":
+			w.(http.Flusher).Flush()
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	defer ts.Close()
+
+	for _, big := range []bool{false, true} {
+		if big {
+			defer func(n int64) { bigSize = n }(bigSize)
+			bigSize = 100
+		}
+		tests := []struct {
+			name    string
+			path    string
+			wantErr bool
+		}{
+			{name: "ok", path: "/ok"},
+			{name: "unknown size", path: "/chunked"},
+			{name: "truncated", path: "/truncated", wantErr: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name+"/big="+strconv.FormatBool(big), func(t *testing.T) {
+				localPath := filepath.Join(t.TempDir(), "download.txt")
+				err := HttpDownload(ts.URL, localPath, tt.path)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("HttpDownload() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				got, rerr := os.ReadFile(localPath)
+				if tt.wantErr {
+					if !os.IsNotExist(rerr) {
+						t.Errorf("HttpDownload() left a partial file of %d bytes", len(got))
+					}
+					return
+				}
+				if len(got) != 1000 {
+					t.Errorf("HttpDownload() file size = %d, want 1000", len(got))
+				}
+			})
+		}
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return fn(req) }
+
+func TestHttpDownload_SizeMismatch(t *testing.T) {
+	// the stub body ends with a clean io.EOF before Content-Length,
+	// so only the size check of HttpDownload can detect it
+	defer func(c *http.Client) { client = c }(client)
+	client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Length": []string{"2000"}},
+			Body:       io.NopCloser(strings.NewReader(strings.Repeat("a", 1000))),
+			Request:    req,
+		}, nil
+	})}
+	defer func(n int64) { bigSize = n }(bigSize)
+
+	for _, size := range []int64{100, 1 << 20} {
+		bigSize = size
+		t.Run("bigSize="+strconv.FormatInt(size, 10), func(t *testing.T) {
+			localPath := filepath.Join(t.TempDir(), "download.txt")
+			err := HttpDownload("http://example.com/file", localPath)
+			if err == nil || !strings.Contains(err.Error(), "size mismatch") {
+				t.Fatalf("HttpDownload() error = %v, want a size mismatch", err)
+			}
+			if _, rerr := os.Stat(localPath); !os.IsNotExist(rerr) {
+				t.Errorf("HttpDownload() left a partial file")
+			}
+		})
+	}
+}
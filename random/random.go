@@ -19,7 +19,11 @@ package random
 
 import (
 	cRand "crypto/rand"
+	"errors"
+	"io"
 	"math/big"
+	"time"
+	"unicode"
 )
 
 var (
@@ -33,24 +37,47 @@ var (
 	Printables          = Numeric + AsciiAlphabetsLower + AsciiAlphabetsUpper + Punctuation // printable characters
 )
 
+// Reader is the source of randomness read by all the functions, it is crypto/rand.Reader.
+// Overriding it is for tests only (e.g. a deterministic or failing reader),
+// it must not be changed while other goroutines are using the package.
+var Reader io.Reader = cRand.Reader
+
+var errInvalidMax = errors.New("input must be greater than 0")
+
 // Int generates a cryptographically-secure random Int.
 // Provided max must be greater than 0.
 func Int(max int) int {
 	return int(Int64(int64(max)))
 }
 
+// IntErr is like Int but returns an error instead of panicking.
+func IntErr(max int) (int, error) {
+	i, err := Int64Err(int64(max))
+	return int(i), err
+}
+
 // Int64 generates a cryptographically-secure random Int64.
 // Provided max must be greater than 0.
 func Int64(max int64) int64 {
+	i, err := Int64Err(max)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// Int64Err is like Int64 but returns an error instead of panicking,
+// when max is not greater than 0 or the crypto/rand read fails.
+func Int64Err(max int64) (int64, error) {
 	if max <= 0 {
-		panic("input must be greater than 0")
+		return 0, errInvalidMax
 	}
-	target, err := cRand.Int(cRand.Reader, big.NewInt(max))
+	target, err := cRand.Int(Reader, big.NewInt(max))
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
 
-	return target.Int64()
+	return target.Int64(), nil
 }
 
 // String generates a cryptographically secure string.
@@ -58,6 +85,11 @@ func String(n int) string {
 	return Random(n, AsciiCharacters)
 }
 
+// StringErr is like String but returns an error instead of panicking.
+func StringErr(n int) (string, error) {
+	return RandomErr(n, AsciiCharacters)
+}
+
 // IntRange returns a random integer between a given range.
 func IntRange(min int, max int) int {
 	i := Int(max - min)
@@ -72,18 +104,121 @@ func IntRange64(min int64, max int64) int64 {
 	return i
 }
 
+// Duration returns a random duration in the half-open range [min, max).
+// If max is not greater than min, it returns min instead of panicking,
+// so a fixed retry delay can be expressed as Duration(d, d).
+func Duration(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return time.Duration(IntRange64(int64(min), int64(max)))
+}
+
 // Random is responsible for generating random data from a given character set.
 func Random(n int, charset string) string {
-	var charsetByte = []byte(charset)
+	s, err := RandomErr(n, charset)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// RandomErr is like Random but returns an error instead of panicking.
+func RandomErr(n int, charset string) (string, error) {
 	s := make([]byte, n)
+	if err := randomInto(s, charset); err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// RandomInto is like Random but fills dst in place with random bytes of charset,
+// so the caller can reuse the buffer. dst itself is used as the random source,
+// so no memory is allocated when the charset has at most 256 characters.
+func RandomInto(dst []byte, charset string) {
+	if err := randomInto(dst, charset); err != nil {
+		panic(err)
+	}
+}
+
+func randomInto(dst []byte, charset string) error {
+	if len(dst) == 0 {
+		// nothing to draw, an empty charset is fine
+		return nil
+	}
 	max := len(charset)
-	for i := range s {
-		s[i] = charsetByte[Int(max)]
+	if max <= 0 {
+		return errInvalidMax
+	}
+	if max > 256 {
+		for i := range dst {
+			j, err := IntErr(max)
+			if err != nil {
+				return err
+			}
+			dst[i] = charset[j]
+		}
+		return nil
+	}
+
+	if _, err := io.ReadFull(Reader, dst); err != nil {
+		return err
+	}
+	// the bytes >= limit are redrawn to avoid the modulo bias
+	limit := 256 - 256%max
+	for i := range dst {
+		for int(dst[i]) >= limit {
+			if _, err := io.ReadFull(Reader, dst[i:i+1]); err != nil {
+				return err
+			}
+		}
+		dst[i] = charset[int(dst[i])%max]
+	}
+	return nil
+}
+
+// StringFromRanges generates a cryptographically secure string of n runes chosen uniformly
+// among all the runes of the ranges, e.g. StringFromRanges(8, unicode.Hiragana).
+// It panics if the ranges have no rune.
+func StringFromRanges(n int, ranges ...*unicode.RangeTable) string {
+	var total int64
+	for _, rt := range ranges {
+		for _, r := range rt.R16 {
+			total += int64((r.Hi-r.Lo)/r.Stride) + 1
+		}
+		for _, r := range rt.R32 {
+			total += int64((r.Hi-r.Lo)/r.Stride) + 1
+		}
 	}
 
+	s := make([]rune, n)
+	for i := range s {
+		s[i] = runeAt(Int64(total), ranges)
+	}
 	return string(s)
 }
 
+// runeAt returns the i-th rune of the ranges.
+func runeAt(i int64, ranges []*unicode.RangeTable) rune {
+	for _, rt := range ranges {
+		for _, r := range rt.R16 {
+			if n := int64((r.Hi-r.Lo)/r.Stride) + 1; i >= n {
+				i -= n
+			} else {
+				return rune(r.Lo) + rune(i)*rune(r.Stride)
+			}
+		}
+		for _, r := range rt.R32 {
+			if n := int64((r.Hi-r.Lo)/r.Stride) + 1; i >= n {
+				i -= n
+			} else {
+				return rune(r.Lo) + rune(i)*rune(r.Stride)
+			}
+		}
+	}
+	panic("unreachable")
+}
+
 // Choice makes a random choice from a slice.
 func Choice[T comparable](datas []T) T {
 	return datas[Int(len(datas))]
@@ -100,3 +235,24 @@ func ChoiceSlice[T comparable](datas []T, n int) []T {
 	}
 	return slice
 }
+
+// Indices returns k random indices in [0, n), chosen with replacement,
+// so k may be greater than n. Provided n must be greater than 0.
+// It is useful to pick elements of a large slice without copying them.
+// A k less than 1 returns an empty slice.
+func Indices(n, k int) []int {
+	if k < 1 {
+		return []int{}
+	}
+	indices := make([]int, k)
+	for i := 0; i < k; i++ {
+		indices[i] = Int(n)
+	}
+	return indices
+}
+
+// ChoiceFunc makes a random choice of an index in [0, n) and calls fn with it.
+// Provided n must be greater than 0.
+func ChoiceFunc(n int, fn func(i int)) {
+	fn(Int(n))
+}
@@ -0,0 +1,231 @@
+// Package random
+/*
+ * Version: 1.0.0
+ * Copyright (c) 2026. Pashifika
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package random
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+	"unicode"
+)
+
+func TestDuration(t *testing.T) {
+	type args struct {
+		min time.Duration
+		max time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{name: "millisecond", args: args{min: 100 * time.Millisecond, max: 500 * time.Millisecond}},
+		{name: "nanosecond", args: args{min: 0, max: 2}},
+		{name: "negative", args: args{min: -time.Second, max: time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if got := Duration(tt.args.min, tt.args.max); got < tt.args.min || got >= tt.args.max {
+					t.Fatalf("Duration() = %v, want in [%v, %v)", got, tt.args.min, tt.args.max)
+				}
+			}
+		})
+	}
+	t.Run("min equal max", func(t *testing.T) {
+		if got := Duration(time.Second, time.Second); got != time.Second {
+			t.Errorf("Duration() = %v, want %v", got, time.Second)
+		}
+	})
+	t.Run("min greater than max", func(t *testing.T) {
+		if got := Duration(time.Second, time.Millisecond); got != time.Second {
+			t.Errorf("Duration() = %v, want %v", got, time.Second)
+		}
+	})
+}
+
+func TestIndices(t *testing.T) {
+	type args struct {
+		n int
+		k int
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{name: "k less than n", args: args{n: 10, k: 3}, want: 3},
+		{name: "k greater than n", args: args{n: 2, k: 50}, want: 50},
+		{name: "k zero", args: args{n: 5, k: 0}, want: 0},
+		{name: "k negative", args: args{n: 5, k: -1}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Indices(tt.args.n, tt.args.k)
+			if len(got) != tt.want {
+				t.Fatalf("Indices() len = %d, want %d", len(got), tt.want)
+			}
+			for _, i := range got {
+				if i < 0 || i >= tt.args.n {
+					t.Errorf("Indices() = %d, want in [0, %d)", i, tt.args.n)
+				}
+			}
+		})
+	}
+}
+
+func TestChoiceFunc(t *testing.T) {
+	datas := []string{"a", "b", "c"}
+	called := 0
+	for i := 0; i < 100; i++ {
+		ChoiceFunc(len(datas), func(i int) {
+			called++
+			if i < 0 || i >= len(datas) {
+				t.Errorf("ChoiceFunc() index = %d, want in [0, %d)", i, len(datas))
+			}
+		})
+	}
+	if called != 100 {
+		t.Errorf("ChoiceFunc() called = %d, want %d", called, 100)
+	}
+}
+
+func TestRandomInto(t *testing.T) {
+	dst := make([]byte, 64)
+	RandomInto(dst, Hexadecimal)
+	for _, c := range dst {
+		if !strings.ContainsRune(Hexadecimal, rune(c)) {
+			t.Fatalf("RandomInto() = %q, %q not in the charset", dst, c)
+		}
+	}
+	long := strings.Repeat(AsciiAlphabets, 6)
+	RandomInto(dst, long)
+	if strings.Trim(string(dst), AsciiAlphabets) != "" {
+		t.Fatalf("RandomInto() = %q, not in the charset", dst)
+	}
+	if got := Random(16, Numeric); len(got) != 16 || strings.Trim(got, Numeric) != "" {
+		t.Errorf("Random() = %q, want 16 numeric characters", got)
+	}
+	if got := Random(0, ""); got != "" {
+		t.Errorf("Random() = %q, want empty", got)
+	}
+	if _, err := RandomErr(1, ""); err == nil {
+		t.Errorf("RandomErr() error = nil, want an error for the empty charset")
+	}
+}
+
+func BenchmarkRandom(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Random(32, AsciiCharacters)
+	}
+}
+
+func BenchmarkRandomInto(b *testing.B) {
+	dst := make([]byte, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		RandomInto(dst, AsciiCharacters)
+	}
+}
+
+func TestErrVariants(t *testing.T) {
+	errRead := errors.New("read failed")
+	defer func(r io.Reader) { Reader = r }(Reader)
+	Reader = iotest.ErrReader(errRead)
+
+	if _, err := IntErr(10); !errors.Is(err, errRead) {
+		t.Errorf("IntErr() error = %v, want %v", err, errRead)
+	}
+	if _, err := Int64Err(10); !errors.Is(err, errRead) {
+		t.Errorf("Int64Err() error = %v, want %v", err, errRead)
+	}
+	if _, err := StringErr(10); !errors.Is(err, errRead) {
+		t.Errorf("StringErr() error = %v, want %v", err, errRead)
+	}
+	if _, err := RandomErr(10, strings.Repeat(Numeric, 30)); !errors.Is(err, errRead) {
+		t.Errorf("RandomErr() error = %v, want %v", err, errRead)
+	}
+	if _, err := Int64Err(0); err == nil {
+		t.Errorf("Int64Err() error = nil, want invalid max")
+	}
+
+	defer func() {
+		if r := recover(); r != errRead {
+			t.Errorf("String() panic = %v, want %v", r, errRead)
+		}
+	}()
+	String(10)
+}
+
+func TestReader(t *testing.T) {
+	defer func(r io.Reader) { Reader = r }(Reader)
+	seed := []byte{0, 1, 2, 3, 9, 10, 255, 62, 200}
+
+	Reader = bytes.NewReader(seed)
+	got := Random(8, Numeric)
+	// 255 is redrawn by the next byte 200 (250 is the limit of 10 characters)
+	if want := "01239002"; got != want {
+		t.Errorf("Random() = %q, want %q", got, want)
+	}
+
+	Reader = bytes.NewReader(seed)
+	first := Int64(1 << 40)
+	Reader = bytes.NewReader(seed)
+	if second := Int64(1 << 40); first != second {
+		t.Errorf("Int64() = %d and %d, want deterministic output", first, second)
+	}
+}
+
+func TestStringFromRanges(t *testing.T) {
+	stride := &unicode.RangeTable{R16: []unicode.Range16{{Lo: 'a', Hi: 'e', Stride: 2}}}
+	tests := []struct {
+		name   string
+		ranges []*unicode.RangeTable
+	}{
+		{name: "hiragana", ranges: []*unicode.RangeTable{unicode.Hiragana}},
+		{name: "combined", ranges: []*unicode.RangeTable{unicode.Greek, unicode.Katakana}},
+		{name: "stride", ranges: []*unicode.RangeTable{stride}},
+		{name: "r32", ranges: []*unicode.RangeTable{{R32: []unicode.Range32{{Lo: 0x1F600, Hi: 0x1F64F, Stride: 1}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := StringFromRanges(200, tt.ranges...)
+			if n := len([]rune(s)); n != 200 {
+				t.Fatalf("StringFromRanges() runes = %d, want 200", n)
+			}
+			for _, r := range s {
+				if !unicode.In(r, tt.ranges...) {
+					t.Fatalf("StringFromRanges() rune %q is not in the ranges", r)
+				}
+			}
+		})
+	}
+
+	// every rune of the ranges can be chosen
+	want := []rune{'a', 'c', 'e', 'x'}
+	ranges := []*unicode.RangeTable{stride, {R16: []unicode.Range16{{Lo: 'x', Hi: 'x', Stride: 1}}}}
+	for i, r := range want {
+		if got := runeAt(int64(i), ranges); got != r {
+			t.Errorf("runeAt(%d) = %q, want %q", i, got, r)
+		}
+	}
+}
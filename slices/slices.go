@@ -18,6 +18,10 @@
 package slices
 
 import (
+	"container/heap"
+	"fmt"
+	"sort"
+
 	"golang.org/x/exp/constraints"
 )
 
@@ -73,6 +77,27 @@ func MergeNotDuplicateFunc[E comparable, K constraints.Ordered](s []E, eq func(e
 	return res
 }
 
+// MergeNotDuplicateLastFunc is like MergeNotDuplicateFunc but the last occurrence wins:
+// the result keeps the position where a key first occurs, and holds the value of
+// the last element with that key (e.g. layering config entries, later slices override earlier ones).
+func MergeNotDuplicateLastFunc[E any, K constraints.Ordered](s []E, eq func(e E) K, m ...[]E) []E {
+	var res []E
+	index := make(map[K]int)
+	for _, rows := range append([][]E{s}, m...) {
+		for _, row := range rows {
+			key := eq(row)
+			if i, ok := index[key]; ok {
+				res[i] = row
+				continue
+			}
+			index[key] = len(res)
+			res = append(res, row)
+		}
+	}
+
+	return res
+}
+
 func FilterFunc[S ~[]E, E, T any](x S, target T, cmp func(E, T) bool) (int, S) {
 	n := 0
 	arr := x[:0]
@@ -84,3 +109,313 @@ func FilterFunc[S ~[]E, E, T any](x S, target T, cmp func(E, T) bool) (int, S) {
 	}
 	return n, arr
 }
+
+// Batch calls fn with each consecutive batch of s of the given size, the last batch
+// may be shorter. It stops and returns the first error returned by fn.
+// The batches alias s (their capacity is clipped to the batch), so fn must copy
+// a batch if it keeps it after returning. Batch panics if size is less than 1.
+func Batch[E any](s []E, size int, fn func(batch []E) error) error {
+	if size < 1 {
+		panic("slices.Batch: size cannot be less than 1")
+	}
+	for i := 0; i < len(s); i += size {
+		end := i + size
+		if end > len(s) {
+			end = len(s)
+		}
+		if err := fn(s[i:end:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Equal reports whether two slices are equal: the same length and all
+// elements equal in order. Empty and nil slices are considered equal.
+func Equal[E comparable](a, b []E) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualFunc is like Equal but uses an equality function on each pair of elements.
+func EqualFunc[E any](a, b []E, eq func(E, E) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove returns a new slice of s without the elements equal to v, preserving the order.
+// Unlike FilterFunc, s is not modified.
+func Remove[E comparable](s []E, v E) []E {
+	return RemoveFunc(s, func(e E) bool { return e == v })
+}
+
+// RemoveFunc returns a new slice of s without the elements that pred returns true for,
+// preserving the order. Unlike FilterFunc, s is not modified.
+func RemoveFunc[E any](s []E, pred func(E) bool) []E {
+	res := make([]E, 0, len(s))
+	for _, e := range s {
+		if !pred(e) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// Insert inserts the values vs... into s at index, returning the modified slice.
+// The elements at s[index:] are shifted up to make room.
+// In the returned slice r, r[index] == vs[0], and r[index+len(vs)] == value originally at s[index].
+// Insert panics if index is out of range [0, len(s)].
+//
+// see more: golang.org/x/exp/slices
+func Insert[E any](s []E, index int, vs ...E) []E {
+	if index < 0 || index > len(s) {
+		panic(fmt.Sprintf("slices.Insert: index %d out of range [0:%d]", index, len(s)))
+	}
+	n := len(s) + len(vs)
+	if n > cap(s) {
+		// append grows the capacity geometrically, so repeated inserts stay amortized O(1) allocations
+		res := append(s[:index], make([]E, n-index)...)
+		copy(res[index:], vs)
+		copy(res[index+len(vs):], s[index:])
+		return res
+	}
+	s = s[:n]
+	copy(s[index+len(vs):], s[index:])
+	copy(s[index:], vs)
+	return s
+}
+
+// InsertAt is like Insert but returns an error instead of panicking when index is out of range.
+func InsertAt[E any](s []E, index int, vs ...E) ([]E, error) {
+	if index < 0 || index > len(s) {
+		return s, fmt.Errorf("slices.InsertAt: index %d out of range [0:%d]", index, len(s))
+	}
+	return Insert(s, index, vs...), nil
+}
+
+// Min returns the minimal value in s. It panics if s is empty.
+// For floating-point numbers, Min propagates NaNs only when it is the first element.
+func Min[E constraints.Ordered](s []E) E {
+	if len(s) < 1 {
+		panic("slices.Min: empty list")
+	}
+	m := s[0]
+	for _, e := range s[1:] {
+		if e < m {
+			m = e
+		}
+	}
+	return m
+}
+
+// Max returns the maximal value in s. It panics if s is empty.
+// For floating-point numbers, Max propagates NaNs only when it is the first element.
+func Max[E constraints.Ordered](s []E) E {
+	if len(s) < 1 {
+		panic("slices.Max: empty list")
+	}
+	m := s[0]
+	for _, e := range s[1:] {
+		if e > m {
+			m = e
+		}
+	}
+	return m
+}
+
+// Sum returns the sum of the values in s, or zero if s is empty.
+// The integer sum may overflow the same as the + operator.
+func Sum[E constraints.Integer | constraints.Float](s []E) E {
+	var sum E
+	for _, e := range s {
+		sum += e
+	}
+	return sum
+}
+
+// SortedInsert inserts v into the sorted slice s at the position that keeps it sorted
+// (after the elements equal to v), returning the modified slice.
+func SortedInsert[E constraints.Ordered](s []E, v E) []E {
+	i := sort.Search(len(s), func(i int) bool { return s[i] > v })
+	return Insert(s, i, v)
+}
+
+// SortedInsertFunc is like SortedInsert but uses a less function,
+// s must be sorted by the same function.
+func SortedInsertFunc[E any](s []E, v E, less func(a, b E) bool) []E {
+	i := sort.Search(len(s), func(i int) bool { return less(v, s[i]) })
+	return Insert(s, i, v)
+}
+
+// DistinctConsecutive returns a new slice of s where the runs of consecutive equal elements
+// are collapsed to their first element, preserving the order. Unlike MergeNotDuplicate,
+// the equal elements which are not neighbors are kept, so s is usually sorted first.
+// s is not modified.
+func DistinctConsecutive[E comparable](s []E) []E {
+	return DistinctConsecutiveFunc(s, func(a, b E) bool { return a == b })
+}
+
+// DistinctConsecutiveFunc is like DistinctConsecutive but uses eq to compare the neighbors.
+func DistinctConsecutiveFunc[E any](s []E, eq func(a, b E) bool) []E {
+	res := make([]E, 0, len(s))
+	for i, e := range s {
+		if i > 0 && eq(res[len(res)-1], e) {
+			continue
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+// Window returns the overlapping windows of s of the given size, in order,
+// that is len(s)-size+1 windows, or nil when size is greater than len(s).
+// The windows alias s (their capacity is clipped to the window), so modifying
+// an element of s is visible in all the windows containing it.
+// Window panics if size is less than 1.
+func Window[E any](s []E, size int) [][]E {
+	if size < 1 {
+		panic("slices.Window: size cannot be less than 1")
+	}
+	if size > len(s) {
+		return nil
+	}
+	res := make([][]E, 0, len(s)-size+1)
+	for i := 0; i+size <= len(s); i++ {
+		res = append(res, s[i:i+size:i+size])
+	}
+	return res
+}
+
+// Find returns the first element of s that pred returns true for and true,
+// or the zero value and false if there is none.
+func Find[E any](s []E, pred func(E) bool) (E, bool) {
+	for _, e := range s {
+		if pred(e) {
+			return e, true
+		}
+	}
+	var zero E
+	return zero, false
+}
+
+// All reports whether pred returns true for all the elements of s, it is true if s is empty.
+// It stops at the first element that pred returns false for.
+func All[E any](s []E, pred func(E) bool) bool {
+	for _, e := range s {
+		if !pred(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any reports whether pred returns true for at least one element of s, it is false if s is empty.
+// It stops at the first element that pred returns true for.
+func Any[E any](s []E, pred func(E) bool) bool {
+	for _, e := range s {
+		if pred(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Coalesce returns the first value of vals which is not the zero value of E,
+// or the zero value if there is none.
+func Coalesce[E comparable](vals ...E) E {
+	var zero E
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// Clone returns a copy of s with its own backing array, so modifying one does not affect the other.
+// The elements are copied by assignment (a shallow copy of each element).
+// The result of a nil s is nil, and an empty non-nil s gives an empty non-nil slice.
+func Clone[E any](s []E) []E {
+	if s == nil {
+		return nil
+	}
+	res := make([]E, len(s))
+	copy(res, s)
+	return res
+}
+
+// CompactByKey returns a new slice of s where each run of consecutive elements with an equal key
+// is collapsed to its last element, preserving the order. s is expected to be grouped (e.g. sorted)
+// by key, unlike DistinctConsecutive which keeps the first element of a run. s is not modified.
+func CompactByKey[E any, K comparable](s []E, key func(E) K) []E {
+	res := make([]E, 0, len(s))
+	var last K
+	for i, e := range s {
+		k := key(e)
+		if i > 0 && k == last {
+			res[len(res)-1] = e
+			continue
+		}
+		res = append(res, e)
+		last = k
+	}
+	return res
+}
+
+// MergeSortedNotDuplicate merges the sorted slices into a new sorted slice without duplicate entries.
+// Unlike MergeNotDuplicate, it uses a k-way merge instead of a map, so it is faster and the
+// result is sorted, but each input must be sorted in ascending order (the result is undefined
+// otherwise). For floating-point numbers, the NaNs are not supported.
+func MergeSortedNotDuplicate[E constraints.Ordered](s ...[]E) []E {
+	n := 0
+	h := make(mergeHeap[E], 0, len(s))
+	for _, rows := range s {
+		if len(rows) != 0 {
+			h = append(h, rows)
+			n += len(rows)
+		}
+	}
+	heap.Init(&h)
+
+	res := make([]E, 0, n)
+	for len(h) != 0 {
+		e := h[0][0]
+		if len(res) == 0 || res[len(res)-1] != e {
+			res = append(res, e)
+		}
+		if h[0] = h[0][1:]; len(h[0]) == 0 {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return res
+}
+
+// mergeHeap is a min-heap of non-empty sorted slices ordered by their first element.
+type mergeHeap[E constraints.Ordered] [][]E
+
+func (h mergeHeap[E]) Len() int           { return len(h) }
+func (h mergeHeap[E]) Less(i, j int) bool { return h[i][0] < h[j][0] }
+func (h mergeHeap[E]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap[E]) Push(x any)        { *h = append(*h, x.([]E)) }
+func (h *mergeHeap[E]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -2,7 +2,10 @@
 package slices
 
 import (
+	"errors"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"golang.org/x/exp/constraints"
@@ -92,6 +95,31 @@ func TestMergeNotDuplicateFunc(t *testing.T) {
 	}
 }
 
+func TestMergeNotDuplicateLastFunc(t *testing.T) {
+	type entry struct {
+		key   string
+		value int
+	}
+	key := func(e entry) string { return e.key }
+	s := []entry{{"a", 1}, {"b", 2}, {"c", 3}}
+	m := [][]entry{
+		{{"b", 20}, {"d", 40}},
+		{{"a", 100}, {"b", 200}},
+	}
+
+	first := MergeNotDuplicateFunc(s, key, m...)
+	if want := []entry{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 40}}; !reflect.DeepEqual(first, want) {
+		t.Errorf("MergeNotDuplicateFunc() = %v, want %v", first, want)
+	}
+	last := MergeNotDuplicateLastFunc(s, key, m...)
+	if want := []entry{{"a", 100}, {"b", 200}, {"c", 3}, {"d", 40}}; !reflect.DeepEqual(last, want) {
+		t.Errorf("MergeNotDuplicateLastFunc() = %v, want %v", last, want)
+	}
+	if want := []entry{{"a", 1}, {"b", 2}, {"c", 3}}; !reflect.DeepEqual(s, want) {
+		t.Errorf("MergeNotDuplicateLastFunc() modified the input = %v, want %v", s, want)
+	}
+}
+
 func TestFilterFunc(t *testing.T) {
 	type Person struct {
 		Name string
@@ -169,3 +197,540 @@ func TestFilterFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestBatch(t *testing.T) {
+	type args struct {
+		s    []int
+		size int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    [][]int
+		wantErr bool
+	}{
+		{
+			name: "short last batch",
+			args: args{s: []int{1, 2, 3, 4, 5, 6, 7}, size: 3},
+			want: [][]int{{1, 2, 3}, {4, 5, 6}, {7}},
+		},
+		{
+			name: "exact",
+			args: args{s: []int{1, 2, 3, 4}, size: 2},
+			want: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name: "empty",
+			args: args{s: nil, size: 2},
+			want: nil,
+		},
+		{
+			name:    "stop on error",
+			args:    args{s: []int{1, 2, 3, 4, 5}, size: 2},
+			want:    [][]int{{1, 2}, {3, 4}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]int
+			err := Batch(tt.args.s, tt.args.size, func(batch []int) error {
+				got = append(got, append([]int(nil), batch...))
+				if tt.wantErr && len(got) == 2 {
+					return errors.New("stop")
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Batch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Batch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	type args struct {
+		a []string
+		b []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{name: "equal", args: args{a: []string{"a", "b"}, b: []string{"a", "b"}}, want: true},
+		{name: "nil and empty", args: args{a: nil, b: []string{}}, want: true},
+		{name: "length mismatch", args: args{a: []string{"a", "b"}, b: []string{"a"}}, want: false},
+		{name: "element mismatch", args: args{a: []string{"a", "b"}, b: []string{"a", "c"}}, want: false},
+		{name: "order", args: args{a: []string{"a", "b"}, b: []string{"b", "a"}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.args.a, tt.args.b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	type entry struct {
+		data int
+		name string
+	}
+	eq := func(a, b entry) bool { return a.data == b.data }
+	tests := []struct {
+		name string
+		a    []entry
+		b    []entry
+		want bool
+	}{
+		{name: "equal by key", a: []entry{{1, "a"}, {2, "b"}}, b: []entry{{1, "x"}, {2, "y"}}, want: true},
+		{name: "length mismatch", a: []entry{{1, "a"}}, b: []entry{{1, "a"}, {2, "b"}}, want: false},
+		{name: "element mismatch", a: []entry{{1, "a"}, {2, "b"}}, b: []entry{{1, "a"}, {3, "b"}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualFunc(tt.a, tt.b, eq); got != tt.want {
+				t.Errorf("EqualFunc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	type args struct {
+		s []int
+		v int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []int
+	}{
+		{name: "remove all", args: args{s: []int{1, 2, 1, 3, 1}, v: 1}, want: []int{2, 3}},
+		{name: "not found", args: args{s: []int{1, 2, 3}, v: 4}, want: []int{1, 2, 3}},
+		{name: "empty", args: args{s: nil, v: 1}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]int(nil), tt.args.s...)
+			if got := Remove(tt.args.s, tt.args.v); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Remove() = %v, want %v", got, tt.want)
+			}
+			if !Equal(tt.args.s, input) {
+				t.Errorf("Remove() modified the input = %v, want %v", tt.args.s, input)
+			}
+		})
+	}
+}
+
+func TestRemoveFunc(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6}
+	got := RemoveFunc(s, func(e int) bool { return e%2 == 0 })
+	if want := []int{1, 3, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RemoveFunc() = %v, want %v", got, want)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6}; !Equal(s, want) {
+		t.Errorf("RemoveFunc() modified the input = %v, want %v", s, want)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	type args struct {
+		s     []int
+		index int
+		vs    []int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []int
+		wantErr bool
+	}{
+		{name: "start", args: args{s: []int{1, 2, 3}, index: 0, vs: []int{8, 9}}, want: []int{8, 9, 1, 2, 3}},
+		{name: "middle", args: args{s: []int{1, 2, 3}, index: 1, vs: []int{8, 9}}, want: []int{1, 8, 9, 2, 3}},
+		{name: "end", args: args{s: []int{1, 2, 3}, index: 3, vs: []int{8}}, want: []int{1, 2, 3, 8}},
+		{name: "in capacity", args: args{s: append(make([]int, 0, 10), 1, 2, 3), index: 1, vs: []int{8}}, want: []int{1, 8, 2, 3}},
+		{name: "nothing", args: args{s: []int{1}, index: 1}, want: []int{1}},
+		{name: "negative", args: args{s: []int{1, 2, 3}, index: -1, vs: []int{8}}, want: []int{1, 2, 3}, wantErr: true},
+		{name: "out of range", args: args{s: []int{1, 2, 3}, index: 4, vs: []int{8}}, want: []int{1, 2, 3}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InsertAt(tt.args.s, tt.args.index, tt.args.vs...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InsertAt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InsertAt() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Insert() did not panic")
+					}
+				}()
+				Insert(tt.args.s, tt.args.index, tt.args.vs...)
+			}
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       []int
+		wantMin int
+		wantMax int
+	}{
+		{name: "single", s: []int{3}, wantMin: 3, wantMax: 3},
+		{name: "multiple", s: []int{3, -1, 7, 2}, wantMin: -1, wantMax: 7},
+		{name: "same", s: []int{5, 5, 5}, wantMin: 5, wantMax: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Min(tt.s); got != tt.wantMin {
+				t.Errorf("Min() = %v, want %v", got, tt.wantMin)
+			}
+			if got := Max(tt.s); got != tt.wantMax {
+				t.Errorf("Max() = %v, want %v", got, tt.wantMax)
+			}
+		})
+	}
+	if got := Max([]string{"b", "c", "a"}); got != "c" {
+		t.Errorf("Max() = %v, want %v", got, "c")
+	}
+
+	for name, fn := range map[string]func([]int) int{"Min": Min[int], "Max": Max[int]} {
+		t.Run(name+" empty", func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s() did not panic on empty slice", name)
+				}
+			}()
+			fn(nil)
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	if got := Sum([]int{1, 2, 3, 4}); got != 10 {
+		t.Errorf("Sum() = %v, want %v", got, 10)
+	}
+	if got := Sum([]float64{0.5, 0.25}); got != 0.75 {
+		t.Errorf("Sum() = %v, want %v", got, 0.75)
+	}
+	if got := Sum([]uint8{7}); got != 7 {
+		t.Errorf("Sum() = %v, want %v", got, 7)
+	}
+	if got := Sum[int](nil); got != 0 {
+		t.Errorf("Sum() = %v, want %v", got, 0)
+	}
+}
+
+func TestSortedInsert(t *testing.T) {
+	var s []int
+	for _, v := range []int{5, 1, 4, 1, 9, 2, 6, 5, 3} {
+		s = SortedInsert(s, v)
+		if !sort.IntsAreSorted(s) {
+			t.Fatalf("SortedInsert(%d) = %v, not sorted", v, s)
+		}
+	}
+	if want := []int{1, 1, 2, 3, 4, 5, 5, 6, 9}; !reflect.DeepEqual(s, want) {
+		t.Errorf("SortedInsert() = %v, want %v", s, want)
+	}
+
+	// growing past the capacity must not reallocate on every insert
+	allocs := testing.AllocsPerRun(10, func() {
+		var s []int
+		for i := 1000; i > 0; i-- {
+			s = SortedInsert(s, i)
+		}
+	})
+	if allocs > 50 {
+		t.Errorf("SortedInsert() 1000 inserts allocs = %v, want at most 50", allocs)
+	}
+}
+
+func TestSortedInsertFunc(t *testing.T) {
+	type entry struct {
+		priority int
+		name     string
+	}
+	less := func(a, b entry) bool { return a.priority < b.priority }
+	var s []entry
+	for _, e := range []entry{{3, "a"}, {1, "b"}, {3, "c"}, {2, "d"}, {0, "e"}} {
+		s = SortedInsertFunc(s, e, less)
+	}
+	// the equal elements keep the insertion order
+	want := []entry{{0, "e"}, {1, "b"}, {2, "d"}, {3, "a"}, {3, "c"}}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("SortedInsertFunc() = %v, want %v", s, want)
+	}
+}
+
+func TestDistinctConsecutive(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []int
+		want []int
+	}{
+		{name: "adjacent", s: []int{1, 1, 2, 2, 2, 3}, want: []int{1, 2, 3}},
+		{name: "interleaved", s: []int{1, 2, 1, 2, 2, 1}, want: []int{1, 2, 1, 2, 1}},
+		{name: "no duplicates", s: []int{3, 2, 1}, want: []int{3, 2, 1}},
+		{name: "single", s: []int{1}, want: []int{1}},
+		{name: "empty", s: nil, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]int(nil), tt.s...)
+			if got := DistinctConsecutive(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DistinctConsecutive() = %v, want %v", got, tt.want)
+			}
+			if !Equal(tt.s, input) {
+				t.Errorf("DistinctConsecutive() modified the input = %v, want %v", tt.s, input)
+			}
+		})
+	}
+}
+
+func TestDistinctConsecutiveFunc(t *testing.T) {
+	s := []string{"a", "A", "b", "B", "b", "a"}
+	got := DistinctConsecutiveFunc(s, strings.EqualFold)
+	if want := []string{"a", "b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DistinctConsecutiveFunc() = %v, want %v", got, want)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	type args struct {
+		s    []int
+		size int
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]int
+	}{
+		{name: "size 1", args: args{s: []int{1, 2, 3}, size: 1}, want: [][]int{{1}, {2}, {3}}},
+		{name: "size 2", args: args{s: []int{1, 2, 3, 4}, size: 2}, want: [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{name: "size == len", args: args{s: []int{1, 2, 3}, size: 3}, want: [][]int{{1, 2, 3}}},
+		{name: "size > len", args: args{s: []int{1, 2, 3}, size: 4}, want: nil},
+		{name: "empty", args: args{s: nil, size: 1}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Window(tt.args.s, tt.args.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Window() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	s := []int{1, 2, 3}
+	windows := Window(s, 2)
+	s[1] = 20
+	if windows[0][1] != 20 || windows[1][0] != 20 {
+		t.Errorf("Window() windows do not alias the input = %v", windows)
+	}
+	if w := append(windows[0], 30); s[2] != 3 || w[2] != 30 {
+		t.Errorf("Window() append to a window modified the input = %v", s)
+	}
+}
+
+func TestFind(t *testing.T) {
+	type Person struct {
+		Name string
+		Age  int
+	}
+	people := []Person{
+		{"Alice", 55},
+		{"Gopher1", 45},
+		{"Gopher2", 45},
+		{"Bob", 24},
+	}
+	tests := []struct {
+		name      string
+		pred      func(Person) bool
+		want      Person
+		wantFound bool
+	}{
+		{name: "first match", pred: func(p Person) bool { return p.Age == 45 }, want: Person{"Gopher1", 45}, wantFound: true},
+		{name: "no match", pred: func(p Person) bool { return p.Name == "Gopher" }, want: Person{}, wantFound: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := Find(people, tt.pred)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("Find() = %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestAllAny(t *testing.T) {
+	positive := func(e int) bool { return e > 0 }
+	tests := []struct {
+		name    string
+		s       []int
+		wantAll bool
+		wantAny bool
+	}{
+		{name: "all match", s: []int{1, 2, 3}, wantAll: true, wantAny: true},
+		{name: "some match", s: []int{-1, 2, -3}, wantAll: false, wantAny: true},
+		{name: "no match", s: []int{-1, -2}, wantAll: false, wantAny: false},
+		{name: "empty", s: nil, wantAll: true, wantAny: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := All(tt.s, positive); got != tt.wantAll {
+				t.Errorf("All() = %v, want %v", got, tt.wantAll)
+			}
+			if got := Any(tt.s, positive); got != tt.wantAny {
+				t.Errorf("Any() = %v, want %v", got, tt.wantAny)
+			}
+		})
+	}
+
+	calls := 0
+	count := func(e int) bool { calls++; return e > 0 }
+	if All([]int{1, -1, 2, 3}, count); calls != 2 {
+		t.Errorf("All() called pred %d times, want 2", calls)
+	}
+	calls = 0
+	if Any([]int{-1, 1, 2, 3}, count); calls != 2 {
+		t.Errorf("Any() called pred %d times, want 2", calls)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []int
+		want int
+	}{
+		{name: "first", vals: []int{1, 2}, want: 1},
+		{name: "skip zero", vals: []int{0, 0, 3}, want: 3},
+		{name: "all zero", vals: []int{0, 0}, want: 0},
+		{name: "no input", vals: nil, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Coalesce(tt.vals...); got != tt.want {
+				t.Errorf("Coalesce() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	var p *int
+	if got := Coalesce(p, nil); got != nil {
+		t.Errorf("Coalesce() = %v, want nil", got)
+	}
+}
+
+func TestClone(t *testing.T) {
+	s := []int{1, 2, 3}
+	got := Clone(s)
+	if !Equal(got, s) {
+		t.Fatalf("Clone() = %v, want %v", got, s)
+	}
+	got[0] = 10
+	s[1] = 20
+	if want := []int{10, 2, 3}; !Equal(got, want) {
+		t.Errorf("Clone() is not independent, clone = %v, want %v", got, want)
+	}
+	if want := []int{1, 20, 3}; !Equal(s, want) {
+		t.Errorf("Clone() is not independent, input = %v, want %v", s, want)
+	}
+
+	if got := Clone([]int(nil)); got != nil {
+		t.Errorf("Clone(nil) = %v, want nil", got)
+	}
+	if got := Clone([]int{}); got == nil || len(got) != 0 {
+		t.Errorf("Clone([]int{}) = %#v, want empty non-nil", got)
+	}
+}
+
+func TestCompactByKey(t *testing.T) {
+	type Person struct {
+		Name string
+		Age  int
+	}
+	people := []Person{
+		{"Alice", 55},
+		{"Bob", 24},
+		{"Gopher", 45},
+		{"Gopher", 33},
+		{"Gopher", 31},
+		{"Bob", 25},
+	}
+	sort.SliceStable(people, func(i, j int) bool { return people[i].Name < people[j].Name })
+	input := append([]Person(nil), people...)
+
+	got := CompactByKey(people, func(p Person) string { return p.Name })
+	want := []Person{
+		{"Alice", 55},
+		{"Bob", 25},
+		{"Gopher", 31},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompactByKey() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(people, input) {
+		t.Errorf("CompactByKey() modified the input = %v, want %v", people, input)
+	}
+	if got := CompactByKey([]Person(nil), func(p Person) string { return p.Name }); len(got) != 0 {
+		t.Errorf("CompactByKey() = %v, want empty", got)
+	}
+}
+
+func TestMergeSortedNotDuplicate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    [][]int
+		want []int
+	}{
+		{name: "interleaved", s: [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, want: []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{name: "duplicates across", s: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, want: []int{1, 2, 3, 4, 5}},
+		{name: "duplicates within", s: [][]int{{1, 1, 2, 2}, {2, 2, 3}}, want: []int{1, 2, 3}},
+		{name: "empty inputs", s: [][]int{nil, {1, 3}, {}, {2}}, want: []int{1, 2, 3}},
+		{name: "single", s: [][]int{{-1, 0, 0, 5}}, want: []int{-1, 0, 5}},
+		{name: "no input", s: nil, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeSortedNotDuplicate(tt.s...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeSortedNotDuplicate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func sortedBenchInputs() [][]int {
+	inputs := make([][]int, 8)
+	for i := range inputs {
+		inputs[i] = make([]int, 1000)
+		for j := range inputs[i] {
+			inputs[i][j] = j*(i+1) + i
+		}
+	}
+	return inputs
+}
+
+func BenchmarkMergeSortedNotDuplicate(b *testing.B) {
+	inputs := sortedBenchInputs()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = MergeSortedNotDuplicate(inputs...)
+	}
+}
+
+func BenchmarkMergeNotDuplicateSorted(b *testing.B) {
+	inputs := sortedBenchInputs()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = MergeNotDuplicate(inputs[0], inputs[1:]...)
+	}
+}